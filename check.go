@@ -0,0 +1,406 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/scanner"
+)
+
+// Check returns all problems found by validating the Proto and resolving its types, sorted by position.
+// Imports are loaded using the opener ; problems with loading them are reported too.
+// The checks include duplicate and invalid field numbers, use of reserved numbers and names, enum values,
+// empty oneofs, unresolved types, types of undeclared imports and, for proto3, JSON name collisions and proto2 only constructs.
+func Check(p *Proto, opener func(filename string) (io.Reader, error)) (list []error) {
+	table := NewSymbolTable(opener)
+	if err := table.Load(p); err != nil {
+		list = append(list, err)
+	}
+	proto3 := p.syntaxValue() == "proto3"
+	list = append(list, CheckMergeCollisions(p)...)
+	list = append(list, CheckSyntaxFeatures(p)...)
+	Walk(p, func(v Visitee) {
+		switch e := v.(type) {
+		case *Message:
+			if e.IsExtend {
+				return
+			}
+			list = append(list, checkFieldNumbers(e.Name, e.Elements)...)
+			if proto3 {
+				list = append(list, CheckJSONNameCollisions(e)...)
+			}
+		case *Group:
+			list = append(list, checkFieldNumbers(e.Name, e.Elements)...)
+		case *Oneof:
+			if len(numberedFields(e.Elements)) == 0 {
+				list = append(list, newCheckError(e.Position, "oneof %q must have at least one field", e.Name))
+			}
+		case *Enum:
+			list = append(list, checkEnumValues(e, proto3)...)
+		}
+	})
+	for _, each := range collectTypeReferences(p) {
+		s, ok := table.Lookup(each.Name, each.Scope)
+		if !ok {
+			list = append(list, newCheckError(each.Position, "type %q is not defined", each.Name))
+			continue
+		}
+		if _, isMessage := s.Type.(*Message); !isMessage {
+			switch each.Element.(type) {
+			case *RPC, *Message:
+				list = append(list, newCheckError(each.Position, "type %q is not a message", each.Name))
+			}
+		}
+	}
+	list = append(list, CheckImportsDeclared(p, table)...)
+	sort.SliceStable(list, func(i, j int) bool {
+		return positionBefore(errorPosition(list[i]), errorPosition(list[j]))
+	})
+	return
+}
+
+// checkError is a problem found at a position in a source.
+type checkError struct {
+	position scanner.Position
+	message  string
+}
+
+func newCheckError(pos scanner.Position, format string, args ...interface{}) error {
+	return checkError{position: pos, message: fmt.Sprintf(format, args...)}
+}
+
+// Error is part of error
+func (e checkError) Error() string {
+	return fmt.Sprintf("%v: %s", e.position, e.message)
+}
+
+// errorPosition returns the position of a checkError ; the zero position for other errors.
+func errorPosition(err error) scanner.Position {
+	if c, ok := err.(checkError); ok {
+		return c.position
+	}
+	return scanner.Position{}
+}
+
+func positionBefore(a, b scanner.Position) bool {
+	if a.Filename != b.Filename {
+		return a.Filename < b.Filename
+	}
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
+
+// numberedField is a field or group with its number.
+type numberedField struct {
+	name     string
+	number   int
+	position scanner.Position
+}
+
+// numberedFields returns the fields and groups of the elements, including those of oneofs, in order of declaration.
+func numberedFields(elements []Visitee) (list []numberedField) {
+	for _, each := range elements {
+		switch e := each.(type) {
+		case *NormalField:
+			list = append(list, numberedField{e.Name, e.Sequence, e.Position})
+		case *MapField:
+			list = append(list, numberedField{e.Name, e.Sequence, e.Position})
+		case *OneOfField:
+			list = append(list, numberedField{e.Name, e.Sequence, e.Position})
+		case *Group:
+			list = append(list, numberedField{e.Name, e.Sequence, e.Position})
+		case *Oneof:
+			list = append(list, numberedFields(e.Elements)...)
+		}
+	}
+	return
+}
+
+// checkFieldNumbers returns an error for each field of a message or group with a duplicate name or number,
+// a number out of range or a reserved number or name.
+func checkFieldNumbers(owner string, elements []Visitee) (list []error) {
+	var reserved []Range
+	reservedNames := map[string]bool{}
+	for _, each := range elements {
+		if r, ok := each.(*Reserved); ok {
+			reserved = append(reserved, r.Ranges...)
+			for _, name := range r.FieldNames {
+				reservedNames[name] = true
+			}
+		}
+	}
+	names := map[string]numberedField{}
+	numbers := map[int]numberedField{}
+	for _, each := range numberedFields(elements) {
+		if first, ok := names[each.name]; ok {
+			list = append(list, newCheckError(each.position, "field %q of %q is already defined at %v", each.name, owner, first.position))
+		} else {
+			names[each.name] = each
+		}
+		switch {
+		case each.number < 1 || each.number > maxFieldNumber:
+			list = append(list, newCheckError(each.position, "number %d of field %q of %q is out of range", each.number, each.name, owner))
+		case firstImplementationReservedNumber <= each.number && each.number <= lastImplementationReservedNumber:
+			list = append(list, newCheckError(each.position, "number %d of field %q of %q is reserved for the implementation", each.number, each.name, owner))
+		case inRanges(each.number, reserved):
+			list = append(list, newCheckError(each.position, "number %d of field %q of %q is reserved", each.number, each.name, owner))
+		}
+		if first, ok := numbers[each.number]; ok {
+			list = append(list, newCheckError(each.position, "number %d of field %q of %q is already used by %q", each.number, each.name, owner, first.name))
+		} else {
+			numbers[each.number] = each
+		}
+		if reservedNames[each.name] {
+			list = append(list, newCheckError(each.position, "name of field %q of %q is reserved", each.name, owner))
+		}
+	}
+	return
+}
+
+// checkEnumValues returns an error for an enum without values, for duplicate names and,
+// unless option allow_alias = true, for duplicate numbers. In proto3, the first value must be zero.
+func checkEnumValues(e *Enum, proto3 bool) (list []error) {
+	allowAlias := false
+	var values []*EnumField
+	for _, each := range e.Elements {
+		switch v := each.(type) {
+		case *Option:
+			if v.Name == "allow_alias" && v.Constant.Source == "true" {
+				allowAlias = true
+			}
+		case *EnumField:
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return append(list, newCheckError(e.Position, "enum %q must have at least one value", e.Name))
+	}
+	if proto3 && values[0].Integer != 0 {
+		list = append(list, newCheckError(values[0].Position, "first value %q of enum %q must be zero in proto3", values[0].Name, e.Name))
+	}
+	names := map[string]*EnumField{}
+	numbers := map[int]*EnumField{}
+	for _, each := range values {
+		if first, ok := names[each.Name]; ok {
+			list = append(list, newCheckError(each.Position, "value %q of enum %q is already defined at %v", each.Name, e.Name, first.Position))
+		} else {
+			names[each.Name] = each
+		}
+		if first, ok := numbers[each.Integer]; ok && !allowAlias {
+			list = append(list, newCheckError(each.Position, "number %d of value %q of enum %q is already used by %q", each.Integer, each.Name, e.Name, first.Name))
+		} else if !ok {
+			numbers[each.Integer] = each
+		}
+	}
+	return
+}
+
+// CheckSyntaxFeatures returns an error for each construct that is not allowed by the proto3 syntax of the Proto,
+// i.e. a required field, a group, an extensions range and a default value of a field.
+// A Proto without syntax declaration is proto2 and has no such errors.
+func CheckSyntaxFeatures(p *Proto) (list []error) {
+	if p.syntaxValue() != "proto3" {
+		return
+	}
+	Walk(p, func(v Visitee) {
+		switch e := v.(type) {
+		case *NormalField:
+			if e.Required {
+				list = append(list, newCheckError(e.Position, "field %q cannot be required in proto3", e.Name))
+			}
+			for _, each := range e.Options {
+				if each.Name == "default" {
+					list = append(list, newCheckError(each.Position, "field %q cannot have a default value in proto3", e.Name))
+				}
+			}
+		case *Group:
+			list = append(list, newCheckError(e.Position, "group %q is not allowed in proto3", e.Name))
+		case *Extensions:
+			list = append(list, newCheckError(e.Position, "extensions are not allowed in proto3"))
+		}
+	})
+	return
+}
+
+// CheckImportsDeclared returns an error for each type reference whose type is defined in a file that is not imported.
+// A file is imported if it is listed as import or is publicly imported by such a file.
+// The table must have loaded the Proto. Unresolved type references are not reported.
+func CheckImportsDeclared(p *Proto, table *SymbolTable) (list []error) {
+	visible := map[string]bool{p.Filename: true}
+	for _, each := range p.Elements {
+		if im, ok := each.(*Import); ok {
+			table.addPublicImports(im.Filename, visible)
+		}
+	}
+	for _, each := range collectTypeReferences(p) {
+		s, ok := table.Lookup(each.Name, each.Scope)
+		if !ok {
+			continue
+		}
+		if !visible[s.Proto.Filename] {
+			list = append(list, newCheckError(each.Position, "type %q is defined in %q which is not imported", each.Name, s.Proto.Filename))
+		}
+	}
+	return
+}
+
+// CheckMergeCollisions returns an error for each top-level message, enum or service
+// that is defined with the same name in the same package by more than one of the definitions.
+func CheckMergeCollisions(protos ...*Proto) (list []error) {
+	type definition struct {
+		pos  scanner.Position
+		kind string
+	}
+	seen := map[string]definition{}
+	for _, each := range protos {
+		pkg := each.packageName()
+		for _, other := range each.Elements {
+			var name string
+			var def definition
+			switch e := other.(type) {
+			case *Message:
+				if e.IsExtend {
+					continue
+				}
+				name, def = e.Name, definition{e.Position, e.groupName()}
+			case *Enum:
+				name, def = e.Name, definition{e.Position, "enum"}
+			case *Service:
+				name, def = e.Name, definition{e.Position, "service"}
+			default:
+				continue
+			}
+			if len(pkg) > 0 {
+				name = pkg + "." + name
+			}
+			if first, ok := seen[name]; ok {
+				list = append(list, newCheckError(def.pos, "%s %q is already defined as %s at %v", def.kind, name, first.kind, first.pos))
+				continue
+			}
+			seen[name] = def
+		}
+	}
+	return
+}
+
+// CheckJSONNameCollisions returns an error for each field of the message that has the same JSON name as a previous field.
+// The JSON name is the value of the json_name option or else CamelCase of the field name. Fields of oneofs are included.
+func CheckJSONNameCollisions(m *Message) (list []error) {
+	seen := map[string]*Field{}
+	for _, each := range messageFields(m) {
+		jsonName := CamelCase(each.Name)
+		for _, o := range each.Options {
+			if o.Name == "json_name" {
+				jsonName = o.Constant.Source
+			}
+		}
+		if first, ok := seen[jsonName]; ok {
+			list = append(list, newCheckError(each.Position, "JSON name %q of field %q collides with field %q at %v", jsonName, each.Name, first.Name, first.Position))
+			continue
+		}
+		seen[jsonName] = each
+	}
+	return
+}
+
+// CheckEnumReferences returns an error for each field with a default value whose type is not an enum
+// and for each such field whose default value is not a value of the enum. Only enums can have a non-scalar default.
+// Fields of messages and of groups are checked. The table must have loaded the Proto.
+func CheckEnumReferences(p *Proto, table *SymbolTable) (list []error) {
+	Walk(p, func(v Visitee) {
+		var fields []*Field
+		switch e := v.(type) {
+		case *Message:
+			fields = messageFields(e)
+		case *Group:
+			fields = elementFields(e.Elements)
+		default:
+			return
+		}
+		for _, each := range fields {
+			var value *Option
+			for _, o := range each.Options {
+				if o.Name == "default" {
+					value = o
+				}
+			}
+			if value == nil || isScalarType(each.Type) {
+				continue
+			}
+			s, ok := table.Lookup(each.Type, v)
+			if !ok {
+				list = append(list, newCheckError(each.Position, "enum type %q of field %q is not defined", each.Type, each.Name))
+				continue
+			}
+			e, ok := s.Type.(*Enum)
+			if !ok {
+				list = append(list, newCheckError(each.Position, "type %q of field %q is not an enum but has a default", each.Type, each.Name))
+				continue
+			}
+			if !hasEnumValue(e, value.Constant.Source) {
+				list = append(list, newCheckError(value.Position, "default %q of field %q is not a value of enum %q", value.Constant.Source, each.Name, s.Name))
+			}
+		}
+	})
+	return
+}
+
+func hasEnumValue(e *Enum, name string) bool {
+	for _, each := range e.Elements {
+		if f, ok := each.(*EnumField); ok && f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// messageFields returns the fields, map fields and oneof fields of a message in order of declaration.
+func messageFields(m *Message) []*Field {
+	return elementFields(m.Elements)
+}
+
+// elementFields returns the fields, map fields and oneof fields of the elements of a message or group in order of declaration.
+func elementFields(elements []Visitee) (list []*Field) {
+	var collect func(elements []Visitee)
+	collect = func(elements []Visitee) {
+		for _, each := range elements {
+			switch e := each.(type) {
+			case *NormalField:
+				list = append(list, e.Field)
+			case *MapField:
+				list = append(list, e.Field)
+			case *OneOfField:
+				list = append(list, e.Field)
+			case *Oneof:
+				collect(e.Elements)
+			}
+		}
+	}
+	collect(elements)
+	return
+}
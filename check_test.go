@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckImportsDeclared(t *testing.T) {
+	def, table := loadSymbolTable(t, `
+package acme;
+import "order.proto";
+import "shared.proto";
+message Invoice {
+	Order order = 1;
+	Money total = 2;
+	Customer customer = 3;
+	Unknown unknown = 4;
+}`, map[string]string{
+		"order.proto":    `package acme; import "customer.proto"; message Order { Customer customer = 1; }`,
+		"customer.proto": `package acme; message Customer {}`,
+		"shared.proto":   `package acme; import public "money.proto";`,
+		"money.proto":    `package acme; message Money {}`,
+	})
+	list := CheckImportsDeclared(def, table)
+	if got, want := len(list), 1; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	msg := list[0].Error()
+	if !strings.HasPrefix(msg, "main.proto:8:2:") {
+		t.Errorf("missing position in %q", msg)
+	}
+	if !strings.Contains(msg, `"customer.proto"`) {
+		t.Errorf("missing import name in %q", msg)
+	}
+}
+
+func TestCheckMergeCollisions(t *testing.T) {
+	parse := func(filename, src string) *Proto {
+		p := newParserOn(src)
+		p.Filename(filename)
+		def, err := p.Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return def
+	}
+	a := parse("a.proto", `package acme;
+message Account {}
+message Order {}`)
+	b := parse("b.proto", `package acme;
+
+message Account {}
+enum Order { NONE = 0; }`)
+	c := parse("c.proto", `package other;
+message Account {}`)
+	list := CheckMergeCollisions(a, b, c)
+	if got, want := len(list), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[0].Error(), `b.proto:3:1: message "acme.Account" is already defined as message at a.proto:2:1`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[1].Error(), `b.proto:4:1: enum "acme.Order" is already defined as message at a.proto:3:1`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestCheckJSONNameCollisions(t *testing.T) {
+	src := `message M {
+	string foo_bar = 1;
+	string fooBar = 2;
+	oneof choice {
+		string other = 3 [json_name = "fooBar"];
+	}
+	string baz = 4 [json_name = "qux"];
+	string qux_ = 5 [json_name = "notQux"];
+}`
+	p := newParserOn(src)
+	p.Filename("m.proto")
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := CheckJSONNameCollisions(collect(def).Messages()[0])
+	if got, want := len(list), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[0].Error(), `m.proto:3:2: JSON name "fooBar" of field "fooBar" collides with field "foo_bar" at m.proto:2:2`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[1].Error(), `m.proto:5:3: JSON name "fooBar" of field "other" collides with field "foo_bar" at m.proto:2:2`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p := newParserOn(`syntax = "proto3";
+package acme;
+import "order.proto";
+import "missing.proto";
+message Invoice {
+	reserved 9;
+	reserved "legacy";
+	Order order = 1;
+	Money total = 1;
+	string legacy = 9;
+	oneof choice {}
+	string user_id = 19000;
+	string userId = 4;
+}
+enum Status {
+	OPEN = 1;
+	CLOSED = 1;
+}
+service Billing {
+	rpc Pay(Status) returns (Invoice);
+}
+message Invoice {}`)
+	p.Filename("main.proto")
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := Check(def, openerOn(map[string]string{
+		"order.proto": `package acme; message Order {}`,
+	}))
+	want := []string{
+		`main.proto:4:1: unable to open import "missing.proto"`,
+		`main.proto:9:2: number 1 of field "total" of "Invoice" is already used by "order"`,
+		`main.proto:9:2: type "Money" is not defined`,
+		`main.proto:10:2: number 9 of field "legacy" of "Invoice" is reserved`,
+		`main.proto:10:2: name of field "legacy" of "Invoice" is reserved`,
+		`main.proto:11:2: oneof "choice" must have at least one field`,
+		`main.proto:12:2: number 19000 of field "user_id" of "Invoice" is reserved for the implementation`,
+		`main.proto:13:2: JSON name "userId" of field "userId" collides with field "user_id"`,
+		`main.proto:16:2: first value "OPEN" of enum "Status" must be zero in proto3`,
+		`main.proto:17:2: number 1 of value "CLOSED" of enum "Status" is already used by "OPEN"`,
+		`main.proto:20:2: type "Status" is not a message`,
+		`main.proto:22:1: message "acme.Invoice" is already defined`,
+	}
+	if got, want := len(list), len(want); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	for i, each := range list {
+		if i < len(want) && !strings.HasPrefix(each.Error(), want[i]) {
+			t.Errorf("[%d] got [%v] want [%v]", i, each, want[i])
+		}
+	}
+}
+
+func TestCheckEnumReferences(t *testing.T) {
+	def, table := loadSymbolTable(t, `syntax = "proto2";
+package acme;
+import "status.proto";
+enum Color { RED = 0; GREEN = 1; }
+message Item {}
+message Order {
+	optional Color color = 1 [default = GREEN];
+	optional Color other = 2 [default = GREN];
+	optional Item item = 3 [default = RED];
+	optional Status status = 4 [default = OPEN];
+	optional Item plain = 5;
+	optional int32 count = 6 [default = 1];
+}`, map[string]string{
+		"status.proto": `package acme; enum Status { OPEN = 0; }`,
+	})
+	list := CheckEnumReferences(def, table)
+	if got, want := len(list), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	for i, each := range []string{
+		`main.proto:8:27: default "GREN" of field "other" is not a value of enum "acme.Color"`,
+		`main.proto:9:11: type "Item" of field "item" is not an enum`,
+	} {
+		if got := list[i].Error(); !strings.HasPrefix(got, each) {
+			t.Errorf("got [%v] want [%v]", got, each)
+		}
+	}
+}
+
+func TestCheckEnumReferencesInGroup(t *testing.T) {
+	def, table := loadSymbolTable(t, `syntax = "proto2";
+package acme;
+message Order {
+	enum Kind { BOOK = 0; }
+	repeated group Line = 1 {
+		enum Unit { PIECE = 0; }
+		optional Unit unit = 2 [default = PIECE];
+		optional Kind kind = 3 [default = BOK];
+	}
+}`, nil)
+	list := CheckEnumReferences(def, table)
+	if got, want := len(list), 1; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[0].Error(), `main.proto:8:26: default "BOK" of field "kind" is not a value of enum "acme.Order.Kind"`; !strings.HasPrefix(got, want) {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
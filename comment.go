@@ -35,6 +35,8 @@ type Comment struct {
 	Lines      []string
 	Cstyle     bool // refers to /* ... */,  C++ style is using //
 	ExtraSlash bool // is true if the comment starts with 3 slashes
+	// Tags has the value of each @tag line of a /** */ doc block, e.g. @since 1.2 ; only set if Parser.ParseCommentTags is true.
+	Tags map[string]string
 }
 
 // newComment returns a comment.
@@ -157,3 +159,54 @@ func consumeCommentFor(p *Parser, e elementContainer) {
 		p.nextPut(pos, tok, lit)
 	}
 }
+
+// isDocBlock returns true if the comment literal is a /** ... */ doc block.
+func isDocBlock(lit string) bool {
+	return strings.HasPrefix(lit, "/**") && strings.HasSuffix(lit, "*/") && lit != "/**/"
+}
+
+// commentTags returns the value of each line of the comment literal that starts with @, after optional leading *.
+// A tag without value has the empty value ; a repeated tag has the value of its last line.
+func commentTags(lit string) map[string]string {
+	tags := map[string]string{}
+	for _, each := range strings.Split(strings.TrimSuffix(lit, "*/"), "\n") {
+		line := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(each), "/*"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		name, value := line[1:], ""
+		if space := strings.IndexAny(name, " \t"); space != -1 {
+			name, value = name[:space], strings.TrimSpace(name[space:])
+		}
+		if len(name) > 0 {
+			tags[name] = value
+		}
+	}
+	return tags
+}
+
+// setCommentTags sets the tags of each comment of the Proto that starts at one of the positions.
+func setCommentTags(proto *Proto, tags map[scanner.Position]map[string]string) {
+	set := func(c *Comment) {
+		if c == nil {
+			return
+		}
+		if each, ok := tags[c.Position]; ok {
+			c.Tags = each
+		}
+	}
+	Walk(proto, func(v Visitee) {
+		switch e := v.(type) {
+		case *Comment:
+			set(e)
+		case Documented:
+			set(e.Doc())
+		case *MapField:
+			set(e.Comment)
+		case *Reserved:
+			set(e.Comment)
+		case *Extensions:
+			set(e.Comment)
+		}
+	})
+}
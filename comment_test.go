@@ -307,3 +307,95 @@ message Foo {
 		t.Fatalf("got [%v] want [%v]", got, want)
 	}
 }
+
+func TestTrailingFileComment(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto3";
+message M {
+	string name = 1;
+}
+
+// end of file
+// no more definitions`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(def.Elements), 3; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if def.Elements[1].(*Message).Comment != nil {
+		t.Error("trailing comment must not be assigned to the message")
+	}
+	c, ok := def.Elements[2].(*Comment)
+	if !ok {
+		t.Fatalf("got [%T] want [*Comment]", def.Elements[2])
+	}
+	if got, want := c.Lines, []string{" end of file", " no more definitions"}; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := c.Position.Line, 6; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestBlockCommentContainingCommentStart(t *testing.T) {
+	def, err := newParserOn(`/* see /* the old docs
+	   and /* more */
+message M {}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(def.Elements), 1; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	m := def.Elements[0].(*Message)
+	if got, want := m.Name, "M"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if m.Comment == nil {
+		t.Fatal("expected comment")
+	}
+	if got, want := m.Comment.Lines[0], " see /* the old docs"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := m.Comment.Lines[1], "	   and /* more "; got != want {
+		t.Errorf("got [%q] want [%q]", got, want)
+	}
+}
+
+func TestCommentTags(t *testing.T) {
+	src := `/**
+ * Account of a customer.
+ * @deprecated use Customer instead
+ * @since 1.2
+ */
+message Account {}
+/* @ignored as not a doc block */
+message Other {}`
+	p := newParserOn(src)
+	p.ParseCommentTags = true
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := def.Elements[0].(*Message).Comment.Tags
+	if got, want := len(tags), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := tags["deprecated"], "use Customer instead"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := tags["since"], "1.2"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got := def.Elements[1].(*Message).Comment.Tags; got != nil {
+		t.Errorf("got [%v] want nil", got)
+	}
+	// opt-in
+	def, err = newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := def.Elements[0].(*Message).Comment.Tags; got != nil {
+		t.Errorf("got [%v] want nil", got)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+// DeprecatedElements returns all messages, fields, enums, enum values, services and rpcs that are marked deprecated.
+// Both the field option form [deprecated = true] and the declaration form option deprecated = true; are detected.
+func DeprecatedElements(p *Proto) (list []Visitee) {
+	Walk(p, func(v Visitee) {
+		if isDeprecated(v) {
+			list = append(list, v)
+		}
+	})
+	return
+}
+
+// EffectiveDeprecated returns true if the element or any of its enclosing elements is marked deprecated.
+// This includes the file option deprecated of the enclosing Proto.
+func EffectiveDeprecated(v Visitee) bool {
+	for v != nil {
+		if p, ok := v.(*Proto); ok {
+			return hasDeprecatedOption(p.Elements)
+		}
+		if isDeprecated(v) {
+			return true
+		}
+		v = getParent(v)
+	}
+	return false
+}
+
+// isDeprecated returns true if the element itself carries the deprecated option with value true.
+func isDeprecated(v Visitee) bool {
+	switch e := v.(type) {
+	case *Message:
+		// an extend cannot be deprecated
+		return !e.IsExtend && hasDeprecatedOption(e.Elements)
+	case *Enum:
+		return hasDeprecatedOption(e.Elements)
+	case *EnumField:
+		return hasDeprecatedOption(e.Elements)
+	case *Service:
+		return hasDeprecatedOption(e.Elements)
+	case *RPC:
+		return hasDeprecatedOption(e.Elements)
+	case *NormalField:
+		return hasDeprecatedFieldOption(e.Options)
+	case *MapField:
+		return hasDeprecatedFieldOption(e.Options)
+	case *OneOfField:
+		return hasDeprecatedFieldOption(e.Options)
+	}
+	return false
+}
+
+func hasDeprecatedOption(elements []Visitee) bool {
+	for _, each := range elements {
+		if o, ok := each.(*Option); ok && isDeprecatedOption(o) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDeprecatedFieldOption(options []*Option) bool {
+	for _, each := range options {
+		if isDeprecatedOption(each) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDeprecatedOption(o *Option) bool {
+	return o.Name == "deprecated" && o.Constant.Source == "true"
+}
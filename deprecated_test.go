@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import "testing"
+
+func TestDeprecatedElements(t *testing.T) {
+	proto := `
+message Account {
+	string id = 1;
+	string name = 2 [deprecated = true];
+	string nick = 3 [deprecated = false];
+}
+service AccountService {
+	rpc Get(Account) returns (Account);
+	rpc Fetch(Account) returns (Account) {
+		option deprecated = true;
+	}
+}`
+	p := newParserOn(proto)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := DeprecatedElements(def)
+	if got, want := len(list), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[0].(*NormalField).Name, "name"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[1].(*RPC).Name, "Fetch"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestDeprecatedElementsEnum(t *testing.T) {
+	proto := `
+enum Color {
+	option deprecated = true;
+	RED = 0;
+	GREEN = 1 [deprecated = true];
+}`
+	p := newParserOn(proto)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := DeprecatedElements(def)
+	if got, want := len(list), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[0].(*Enum).Name, "Color"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[1].(*EnumField).Name, "GREEN"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestEffectiveDeprecated(t *testing.T) {
+	proto := `
+message Old {
+	option deprecated = true;
+	message Nested {
+		oneof choice {
+			string a = 1;
+		}
+	}
+	string b = 2;
+}
+message Current {
+	string c = 1;
+	string d = 2 [deprecated = true];
+}`
+	p := newParserOn(proto)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	Walk(def, func(v Visitee) {
+		switch e := v.(type) {
+		case *NormalField:
+			found[e.Name] = EffectiveDeprecated(e)
+		case *OneOfField:
+			found[e.Name] = EffectiveDeprecated(e)
+		}
+	})
+	for name, want := range map[string]bool{"a": true, "b": true, "c": false, "d": true} {
+		if got := found[name]; got != want {
+			t.Errorf("[%s] got [%v] want [%v]", name, got, want)
+		}
+	}
+}
+
+func TestEffectiveDeprecatedByFile(t *testing.T) {
+	p := newParserOn(`option deprecated = true; service S { rpc R(M) returns (M); }`)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpc := collect(def).Services()[0].Elements[0]
+	if got, want := EffectiveDeprecated(rpc), true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/scanner"
+)
+
+// DocEntry is a comment that documents a declaration.
+type DocEntry struct {
+	// Position is where the declaration starts.
+	Position scanner.Position
+	// Kind is one of message, enum, enumfield, field, service or rpc. A group is a field.
+	Kind string
+	// Name is the name of the declaration prefixed by the names of its enclosing declarations, e.g. Outer.Inner.field
+	Name string
+	// Doc has the comment lines without prefixes //, ///, /* or suffix */ joined by newlines.
+	Doc string
+}
+
+// ExtractDocs scans a proto definition and returns an entry for each message, enum, enum value, field, service and rpc
+// that is preceded by a comment. It does not build the elements of a Proto and reports scanner errors only.
+// A comment documents a declaration if it ends on the line before it and is not an inline comment of a previous statement.
+func ExtractDocs(r io.Reader) ([]DocEntry, error) {
+	x := &docExtractor{parser: NewParser(r)}
+	x.run()
+	if len(x.parser.scannerErrors) > 0 {
+		buf := new(bytes.Buffer)
+		for _, each := range x.parser.scannerErrors {
+			fmt.Fprintln(buf, each)
+		}
+		return x.entries, errors.New(buf.String())
+	}
+	return x.entries, nil
+}
+
+// docScope is a declaration with a body.
+type docScope struct {
+	kind string
+	name string
+}
+
+type docExtractor struct {
+	parser        *Parser
+	entries       []DocEntry
+	scopes        []docScope
+	comment       *Comment // pending
+	lastTokenLine int      // line of the last token that is not a comment
+	statement     []string
+	statementPos  scanner.Position
+	statementDoc  *Comment
+}
+
+func (x *docExtractor) run() {
+	for {
+		pos, tok, lit := x.parser.next()
+		switch {
+		case tEOF == tok:
+			return
+		case tCOMMENT == tok:
+			x.addComment(pos, lit)
+			continue
+		case len(x.statement) == 0:
+			x.statementPos = pos
+			x.statementDoc = x.takeComment(pos.Line - 1)
+		}
+		x.comment = nil
+		x.lastTokenLine = x.parser.scanner.Position.Line
+		switch tok {
+		case tLEFTSQUARE:
+			x.skipBalanced(tLEFTSQUARE, tRIGHTSQUARE)
+		case tLEFTCURLY:
+			if n := len(x.statement); n > 0 && (x.statement[n-1] == "=" || x.statement[n-1] == ":") {
+				// aggregate option value
+				x.skipBalanced(tLEFTCURLY, tRIGHTCURLY)
+				continue
+			}
+			x.openScope()
+		case tSEMICOLON:
+			x.endStatement()
+		case tRIGHTCURLY:
+			x.statement = x.statement[:0]
+			if len(x.scopes) > 0 {
+				x.scopes = x.scopes[:len(x.scopes)-1]
+			}
+		default:
+			x.statement = append(x.statement, lit)
+		}
+	}
+}
+
+// addComment merges the comment with the pending one or replaces it.
+func (x *docExtractor) addComment(pos scanner.Position, lit string) {
+	if pos.Line == x.lastTokenLine {
+		// inline comment of the previous statement
+		return
+	}
+	c := newComment(pos, lit)
+	if x.comment != nil && !x.comment.Cstyle && !c.Cstyle && x.comment.hasTextOnLine(pos.Line-1) {
+		x.comment.Merge(c)
+		return
+	}
+	x.comment = c
+}
+
+// takeComment returns the pending comment if it ends on the given line.
+func (x *docExtractor) takeComment(line int) *Comment {
+	if x.comment == nil || !x.comment.hasTextOnLine(line) {
+		return nil
+	}
+	return x.comment
+}
+
+// skipBalanced consumes tokens up to and including the matching close token ; open has been consumed.
+func (x *docExtractor) skipBalanced(open, close token) {
+	depth := 1
+	for depth > 0 {
+		_, tok, _ := x.parser.next()
+		switch tok {
+		case tEOF:
+			return
+		case open:
+			depth++
+		case close:
+			depth--
+		}
+	}
+	x.lastTokenLine = x.parser.scanner.Position.Line
+}
+
+// openScope handles the start of a body of a declaration.
+func (x *docExtractor) openScope() {
+	s := x.statement
+	scope := docScope{kind: "other"}
+	if len(s) > 1 {
+		switch s[0] {
+		case "message", "enum", "service":
+			scope = docScope{kind: s[0], name: s[1]}
+			x.addEntry(s[0], s[1])
+		case "rpc":
+			scope = docScope{kind: "rpc"}
+			x.addEntry("rpc", s[1])
+		case "oneof", "extend":
+			scope = docScope{kind: s[0]}
+		}
+		// group is a field that defines a message
+		for i, each := range s[:len(s)-1] {
+			if each == "group" && i <= 1 {
+				scope = docScope{kind: "message", name: s[i+1]}
+				x.addEntry("field", s[i+1])
+			}
+		}
+	}
+	x.scopes = append(x.scopes, scope)
+	x.statement = x.statement[:0]
+}
+
+// endStatement handles a statement that ends with a semicolon.
+func (x *docExtractor) endStatement() {
+	s := x.statement
+	x.statement = x.statement[:0]
+	if len(s) == 0 {
+		return
+	}
+	if s[0] == "rpc" && len(s) > 1 {
+		x.addEntry("rpc", s[1])
+		return
+	}
+	if len(x.scopes) == 0 {
+		return
+	}
+	switch s[0] {
+	case "option", "reserved", "extensions":
+		return
+	}
+	switch x.scopes[len(x.scopes)-1].kind {
+	case "enum":
+		x.addEntry("enumfield", s[0])
+	case "message", "oneof", "extend":
+		for i, each := range s {
+			if each == "=" && i > 0 {
+				x.addEntry("field", s[i-1])
+				return
+			}
+		}
+	}
+}
+
+func (x *docExtractor) addEntry(kind, name string) {
+	if x.statementDoc == nil {
+		return
+	}
+	names := []string{}
+	for _, each := range x.scopes {
+		if len(each.name) > 0 {
+			names = append(names, each.name)
+		}
+	}
+	x.entries = append(x.entries, DocEntry{
+		Position: x.statementPos,
+		Kind:     kind,
+		Name:     strings.Join(append(names, name), "."),
+		Doc:      strings.Join(x.statementDoc.Lines, "\n"),
+	})
+	x.statementDoc = nil
+}
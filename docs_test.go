@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractDocs(t *testing.T) {
+	src := `syntax = "proto3";
+// Account holds
+// the details.
+message Account {
+	option (custom) = { doc: "not a field" };
+	// id is unique
+	string id = 1 [(x) = { a: 1 }];
+	string name = 2; // inline
+	int32 age = 3;
+	/* Kind of account */
+	enum Kind {
+		// the default
+		UNKNOWN = 0;
+	}
+	oneof contact {
+		// e-mail address
+		string email = 4;
+	}
+	// Entries by key
+	map<string, Entry> entries = 5;
+	// grp
+	optional group G = 6 {
+		// d is inside
+		optional string d = 7;
+	}
+}
+
+// AccountService manages accounts.
+service AccountService {
+	// Get returns an account.
+	rpc Get(Account) returns (Account);
+	// Watch streams changes.
+	rpc Watch(Account) returns (stream Account) {
+		option deprecated = true;
+	}
+}`
+	list, err := ExtractDocs(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, each := range []struct {
+		kind, name, doc string
+		line            int
+	}{
+		{"message", "Account", " Account holds\n the details.", 4},
+		{"field", "Account.id", " id is unique", 7},
+		{"enum", "Account.Kind", " Kind of account ", 11},
+		{"enumfield", "Account.Kind.UNKNOWN", " the default", 13},
+		{"field", "Account.email", " e-mail address", 17},
+		{"field", "Account.entries", " Entries by key", 20},
+		{"field", "Account.G", " grp", 22},
+		{"field", "Account.G.d", " d is inside", 24},
+		{"service", "AccountService", " AccountService manages accounts.", 29},
+		{"rpc", "AccountService.Get", " Get returns an account.", 31},
+		{"rpc", "AccountService.Watch", " Watch streams changes.", 33},
+	} {
+		if i >= len(list) {
+			t.Fatalf("missing entry [%d] %s", i, each.name)
+		}
+		e := list[i]
+		if got, want := e.Kind, each.kind; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := e.Name, each.name; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := e.Doc, each.doc; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := e.Position.Line, each.line; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+	}
+	if got, want := len(list), 11; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
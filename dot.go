@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"fmt"
+	"io"
+)
+
+// WriteDependencyDOT writes a Graphviz DOT graph of the types of the Proto.
+// Each message (box) and enum (ellipse) is a node ; each field whose type is a message or enum is an edge labeled with the field name.
+// Edges of repeated fields are dashed and edges of map fields are bold.
+// Types that are defined in imported files are gray nodes. The table must have loaded the Proto ; unresolved types are left out.
+func WriteDependencyDOT(p *Proto, w io.Writer, table *SymbolTable) error {
+	d := &dotWriter{w: w, nodes: map[string]bool{}}
+	d.printf("digraph %q {\n", p.Filename)
+	walk(p, func(v Visitee) {
+		switch e := v.(type) {
+		case *Message:
+			if !e.IsExtend {
+				d.node(qualifiedName(e), "shape=box")
+			}
+		case *Group:
+			d.node(qualifiedName(e), "shape=box")
+		case *Enum:
+			d.node(qualifiedName(e), "shape=ellipse")
+		}
+	})
+	walk(p, func(v Visitee) {
+		switch e := v.(type) {
+		case *NormalField:
+			style := ""
+			if e.Repeated {
+				style = " style=dashed"
+			}
+			d.edge(table, e.Field, e.Parent, style)
+		case *MapField:
+			d.edge(table, e.Field, e.Parent, " style=bold")
+		case *OneOfField:
+			d.edge(table, e.Field, e.Parent, "")
+		}
+	})
+	d.printf("}\n")
+	return d.err
+}
+
+// dotWriter keeps the first write error.
+type dotWriter struct {
+	w     io.Writer
+	err   error
+	nodes map[string]bool
+}
+
+func (d *dotWriter) printf(format string, args ...interface{}) {
+	if d.err == nil {
+		_, d.err = fmt.Fprintf(d.w, format, args...)
+	}
+}
+
+func (d *dotWriter) node(name, attributes string) {
+	if d.nodes[name] {
+		return
+	}
+	d.nodes[name] = true
+	d.printf("\t%q [%s];\n", name, attributes)
+}
+
+// edge writes an edge from the type that declares the field to the type of the field.
+// Fields of an extend are not declared by a type and are skipped.
+func (d *dotWriter) edge(table *SymbolTable, f *Field, parent Visitee, style string) {
+	if m, ok := parent.(*Message); (ok && m.IsExtend) || isScalarType(f.Type) {
+		return
+	}
+	s, ok := table.Lookup(f.Type, parent)
+	if !ok {
+		return
+	}
+	if !d.nodes[s.Name] {
+		shape := "box"
+		if _, ok := s.Type.(*Enum); ok {
+			shape = "ellipse"
+		}
+		d.node(s.Name, "shape="+shape+" color=gray")
+	}
+	if _, ok := parent.(*Oneof); ok {
+		parent = getParent(parent)
+	}
+	d.printf("\t%q -> %q [label=%q%s];\n", qualifiedName(parent), s.Name, f.Name, style)
+}
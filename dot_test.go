@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteDependencyDOT(t *testing.T) {
+	def, table := loadSymbolTable(t, `
+package acme;
+import "money.proto";
+message Order {
+	enum Status { OPEN = 0; }
+	Status status = 1;
+	repeated Item items = 2;
+	map<string, Money> totals = 3;
+	oneof payment {
+		Card card = 4;
+	}
+	string note = 5;
+}
+message Item {}
+message Card {}
+extend Order { Item extra = 100; }`, map[string]string{
+		"money.proto": `package acme; message Money {}`,
+	})
+	buf := new(bytes.Buffer)
+	if err := WriteDependencyDOT(def, buf, table); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	if !strings.HasPrefix(dot, `digraph "main.proto" {`) || !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("not a digraph: %s", dot)
+	}
+	for _, each := range []string{
+		`"acme.Order" [shape=box];`,
+		`"acme.Order.Status" [shape=ellipse];`,
+		`"acme.Money" [shape=box color=gray];`,
+		`"acme.Order" -> "acme.Order.Status" [label="status"];`,
+		`"acme.Order" -> "acme.Item" [label="items" style=dashed];`,
+		`"acme.Order" -> "acme.Money" [label="totals" style=bold];`,
+		`"acme.Order" -> "acme.Card" [label="card"];`,
+	} {
+		if !strings.Contains(dot, each) {
+			t.Errorf("missing %s in %s", each, dot)
+		}
+	}
+	if got, want := strings.Count(dot, "->"), 4; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
@@ -64,6 +64,26 @@ func (e *Enum) takeLastComment(expectedOnLine int) (last *Comment) {
 	return
 }
 
+// takeLastDocComment is like takeLastComment but leaves a comment that ends on or before the line of the opening {.
+// Such a comment is part of the enum body and does not document the first value, option or reserved.
+func (e *Enum) takeLastDocComment(expectedOnLine, curlyLine int) *Comment {
+	if len(e.Elements) > 0 {
+		if last, ok := e.Elements[len(e.Elements)-1].(*Comment); ok && last.Position.Line+len(last.Lines)-1 <= curlyLine {
+			return nil
+		}
+	}
+	return e.takeLastComment(expectedOnLine)
+}
+
+// lastCommentStartsOnLine returns true if the last element is a Comment that starts on the line.
+func (e *Enum) lastCommentStartsOnLine(line int) bool {
+	if len(e.Elements) == 0 {
+		return false
+	}
+	last, ok := e.Elements[len(e.Elements)-1].(*Comment)
+	return ok && last.Position.Line == line
+}
+
 func (e *Enum) parse(p *Parser) error {
 	pos, tok, lit := p.next()
 	if tok != tIDENT {
@@ -73,21 +93,29 @@ func (e *Enum) parse(p *Parser) error {
 	}
 	e.Name = lit
 	consumeCommentFor(p, e)
-	_, tok, lit = p.next()
+	pos, tok, lit = p.next()
 	if tok != tLEFTCURLY {
 		return p.unexpected(lit, "enum opening {", e)
 	}
+	curlyLine := pos.Line
 	for {
 		pos, tok, lit = p.next()
 		switch tok {
 		case tCOMMENT:
-			if com := mergeOrReturnComment(e.elements(), lit, pos); com != nil { // not merged?
+			// a comment that starts on the line of the opening { is part of the body; do not merge a following comment into it
+			if e.lastCommentStartsOnLine(curlyLine) {
+				e.addElement(newComment(pos, lit))
+			} else if com := mergeOrReturnComment(e.elements(), lit, pos); com != nil { // not merged?
 				e.addElement(com)
 			}
 		case tOPTION:
 			v := new(Option)
 			v.Position = pos
-			v.Comment = e.takeLastComment(pos.Line)
+			// a comment can precede the option on the same line or on the previous line
+			v.Comment = e.takeLastDocComment(pos.Line, curlyLine)
+			if v.Comment == nil {
+				v.Comment = e.takeLastDocComment(pos.Line-1, curlyLine)
+			}
 			err := v.parse(p)
 			if err != nil {
 				return err
@@ -100,7 +128,7 @@ func (e *Enum) parse(p *Parser) error {
 		case tRESERVED:
 			r := new(Reserved)
 			r.Position = pos
-			r.Comment = e.takeLastComment(pos.Line - 1)
+			r.Comment = e.takeLastDocComment(pos.Line-1, curlyLine)
 			if err := r.parse(p); err != nil {
 				return err
 			}
@@ -109,7 +137,10 @@ func (e *Enum) parse(p *Parser) error {
 			p.nextPut(pos, tok, lit)
 			f := new(EnumField)
 			f.Position = pos
-			f.Comment = e.takeLastComment(pos.Line - 1)
+			f.Comment = e.takeLastDocComment(pos.Line-1, curlyLine)
+			if p.AutoNumberEnums {
+				f.Integer = e.nextInteger()
+			}
 			err := f.parse(p)
 			if err != nil {
 				return err
@@ -127,6 +158,16 @@ done:
 // parent is part of elementContainer
 func (e *Enum) parent(p Visitee) { e.Parent = p }
 
+// nextInteger returns the number after that of the last value ; 0 if there are no values yet.
+func (e *Enum) nextInteger() int {
+	for i := len(e.Elements) - 1; i >= 0; i-- {
+		if f, ok := e.Elements[i].(*EnumField); ok {
+			return f.Integer + 1
+		}
+	}
+	return 0
+}
+
 // EnumField is part of the body of an Enum.
 type EnumField struct {
 	Position scanner.Position
@@ -164,15 +205,18 @@ func (f *EnumField) parse(p *Parser) error {
 	}
 	f.Name = lit
 	pos, tok, lit := p.next()
-	if tok != tEQUALS {
-		return p.unexpected(lit, "enum field =", f)
-	}
-	i, err := p.nextInteger()
-	if err != nil {
-		return p.unexpected(err.Error(), "enum field integer", f)
+	// without number, keep the one assigned by the enum
+	if autoNumbered := p.AutoNumberEnums && (tok == tSEMICOLON || tok == tLEFTSQUARE); !autoNumbered {
+		if tok != tEQUALS {
+			return p.unexpected(lit, "enum field =", f)
+		}
+		i, err := p.nextInteger()
+		if err != nil {
+			return p.unexpected(err.Error(), "enum field integer", f)
+		}
+		f.Integer = i
+		pos, tok, lit = p.next()
 	}
-	f.Integer = i
-	pos, tok, lit = p.next()
 	if tok == tLEFTSQUARE {
 		for {
 			o := new(Option)
@@ -192,6 +236,7 @@ func (f *EnumField) parse(p *Parser) error {
 			if tok == tRIGHTSQUARE {
 				break
 			}
+			return p.unexpected(lit, "option , or ]", f)
 		}
 	}
 	if tSEMICOLON == tok {
@@ -23,7 +23,10 @@
 
 package proto
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestEnum(t *testing.T) {
 	proto := `
@@ -169,3 +172,150 @@ func TestEnumInlineCommentBeforeBody(t *testing.T) {
 		t.Errorf("got %d want %d lines", got, want)
 	}
 }
+
+func TestEnumCommentInsideBody(t *testing.T) {
+	for i, each := range []struct {
+		src          string
+		fieldComment string
+		bodyComment  string
+	}{
+		{"enum E {\n // doc\n A = 0;\n}", " doc", ""},
+		{"enum E { // body\n A = 0;\n}", "", " body"},
+		{"enum E { // body\n // doc\n A = 0;\n}", " doc", " body"},
+		{"enum E {\n // body\n\n A = 0;\n}", "", " body"},
+		{"enum E {\n /* doc */\n A = 0;\n}", " doc ", ""},
+	} {
+		p := newParserOn(each.src)
+		p.next()
+		e := new(Enum)
+		if err := e.parse(p); err != nil {
+			t.Fatal(i, err)
+		}
+		var field *EnumField
+		var body *Comment
+		for _, other := range e.Elements {
+			switch v := other.(type) {
+			case *EnumField:
+				field = v
+			case *Comment:
+				body = v
+			}
+		}
+		if got, want := field.Comment != nil, len(each.fieldComment) > 0; got != want {
+			t.Fatalf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if field.Comment != nil && field.Comment.Message() != each.fieldComment {
+			t.Errorf("[%d] got [%v] want [%v]", i, field.Comment.Message(), each.fieldComment)
+		}
+		if got, want := body != nil, len(each.bodyComment) > 0; got != want {
+			t.Fatalf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if body != nil && body.Message() != each.bodyComment {
+			t.Errorf("[%d] got [%v] want [%v]", i, body.Message(), each.bodyComment)
+		}
+	}
+}
+
+func TestEnumOptionComment(t *testing.T) {
+	p := newParserOn("enum E {\n // doc\n option allow_alias = true;\n A = 0;\n}")
+	p.next()
+	e := new(Enum)
+	if err := e.parse(p); err != nil {
+		t.Fatal(err)
+	}
+	o := e.Elements[0].(*Option)
+	if o.Comment == nil {
+		t.Fatal("expected comment")
+	}
+	if got, want := o.Comment.Message(), " doc"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestEnumOptionCommentOnSameLine(t *testing.T) {
+	p := newParserOn("enum E {\n A = 0;\n /* c */ option allow_alias = true;\n}")
+	p.next()
+	e := new(Enum)
+	if err := e.parse(p); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(e.Elements), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	o := e.Elements[1].(*Option)
+	if o.Comment == nil {
+		t.Fatal("expected comment")
+	}
+	if got, want := o.Comment.Message(), " c "; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestEnumFieldAggregateValueOption(t *testing.T) {
+	src := `enum Color {
+		RED = 1 [(meta) = { hex: "#f00" rgb: [255, 0, 0] }, (other) = 2];
+	}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := def.Elements[0].(*Enum).Elements[0].(*EnumField)
+	if got, want := len(f.Elements), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	meta := f.Elements[0].(*Option)
+	if got, want := meta.Name, "(meta)"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	hex, ok := meta.Constant.OrderedMap.Get("hex")
+	if !ok {
+		t.Fatal("expected hex")
+	}
+	if got, want := hex.Source, "#f00"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := meta.Constant.SourceRepresentation(), `{ hex: "#f00" rgb: [255, 0, 0] }`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := meta.Parent, Visitee(f); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := f.Elements[1].(*Option).Constant.Source, "2"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestEnumFieldOptionsMissingSeparator(t *testing.T) {
+	if _, err := newParserOn(`enum Color { RED = 1 [(a) = 1 (b) = 2]; }`).Parse(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestEnumAutoNumber(t *testing.T) {
+	src := `enum Color {
+		RED;
+		GREEN [deprecated = true];
+		BLUE = 10;
+		CYAN;
+	}`
+	if _, err := newParserOn(src).Parse(); err == nil {
+		t.Fatal("expected error for value without number")
+	}
+	p := newParserOn(src)
+	p.AutoNumberEnums = true
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var numbers []int
+	for _, each := range def.Elements[0].(*Enum).Elements {
+		numbers = append(numbers, each.(*EnumField).Integer)
+	}
+	if got, want := numbers, []int{0, 1, 10, 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	green := def.Elements[0].(*Enum).Elements[1].(*EnumField)
+	if got, want := len(green.Elements), 1; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
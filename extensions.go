@@ -24,6 +24,7 @@
 package proto
 
 import (
+	"sort"
 	"text/scanner"
 )
 
@@ -59,3 +60,38 @@ func (e *Extensions) parse(p *Parser) error {
 
 // parent is part of elementContainer
 func (e *Extensions) parent(p Visitee) { e.Parent = p }
+
+// ExtensionNumberUsage returns the sorted field numbers of all extensions of the message
+// and the ranges of its extensions declarations.
+// Only extend blocks in the same Proto as the message are considered.
+func ExtensionNumberUsage(m *Message) (used []int, ranges []Range) {
+	for _, each := range m.Elements {
+		if e, ok := each.(*Extensions); ok {
+			ranges = append(ranges, e.Ranges...)
+		}
+	}
+	root := RootProto(m)
+	if root == nil {
+		return
+	}
+	table := NewSymbolTable(nil)
+	table.Load(root) // cannot fail without opener
+	Walk(root, WithMessage(func(extend *Message) {
+		if !extend.IsExtend {
+			return
+		}
+		if s, ok := table.Lookup(extend.Name, extend.Parent); !ok || s.Type != m {
+			return
+		}
+		for _, each := range extend.Elements {
+			switch e := each.(type) {
+			case *NormalField:
+				used = append(used, e.Sequence)
+			case *Group:
+				used = append(used, e.Sequence)
+			}
+		}
+	}))
+	sort.Ints(used)
+	return
+}
@@ -23,7 +23,10 @@
 
 package proto
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func TestExtensions(t *testing.T) {
 	proto := `message M {
@@ -57,3 +60,40 @@ func TestExtensions(t *testing.T) {
 		t.Errorf("got [%s] want [%s]", got, want)
 	}
 }
+
+func TestExtensionNumberUsage(t *testing.T) {
+	src := `syntax = "proto2";
+package acme;
+message Base {
+	extensions 100 to 199, 500 to max;
+}
+extend Base {
+	optional string b = 150;
+	optional group G = 101 {}
+}
+message Other {
+	extend acme.Base {
+		optional int32 a = 100;
+	}
+}
+message Unrelated {
+	extensions 10 to 20;
+}
+extend .acme.Unrelated {
+	optional int32 u = 10;
+}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	used, ranges := ExtensionNumberUsage(collect(def).Messages()[0])
+	if got, want := fmt.Sprint(used), "[100 101 150]"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := len(ranges), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := ranges[1].SourceRepresentation(), "500 to max"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
@@ -64,6 +64,20 @@ func (f *NormalField) Doc() *Comment {
 	return f.Comment
 }
 
+// IsImportedType returns true if the type of the field is defined in another file than the one of this field.
+// A field with a scalar type or with a type defined in the same file is not imported.
+// If the type cannot be found in the table then it is considered to be imported from an unknown file.
+func (f *NormalField) IsImportedType(table *SymbolTable) bool {
+	if isScalarType(f.Type) {
+		return false
+	}
+	s, ok := table.Lookup(f.Type, f.Parent)
+	if !ok {
+		return true
+	}
+	return s.Proto != RootProto(f)
+}
+
 // parse expects:
 // [ "repeated" | "optional" ] type fieldName "=" fieldNumber [ "[" fieldOptions "]" ] ";"
 func (f *NormalField) parse(p *Parser) error {
@@ -76,7 +90,8 @@ func (f *NormalField) parse(p *Parser) error {
 		case tOPTIONAL: // proto2
 			f.Optional = true
 			return f.parse(p)
-		case tIDENT:
+		case tIDENT, tSTREAM:
+			// stream is only a keyword inside rpc parentheses
 			f.Type = lit
 			return parseFieldAfterType(f.Field, p, f)
 		default:
@@ -167,7 +182,7 @@ func (f *MapField) parse(p *Parser) error {
 		return p.unexpected(lit, "map type separator ,", f)
 	}
 	_, tok, lit = p.nextTypeName()
-	if tIDENT != tok {
+	if tIDENT != tok && tSTREAM != tok {
 		return p.unexpected(lit, "map valueType identifier", f)
 	}
 	f.Type = lit
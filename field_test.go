@@ -24,6 +24,7 @@
 package proto
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -209,3 +210,68 @@ func TestMultiLineFieldType(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestFieldBuiltinAndCustomOptions(t *testing.T) {
+	proto := `repeated int32 x = 1 [packed = true, json_name = "xs", (my.opt) = 5];`
+	p := newParserOn(proto)
+	f := newNormalField()
+	err := f.parse(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(f.Options), 3; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	for i, each := range []struct {
+		name, source string
+		isString     bool
+	}{
+		{"packed", "true", false},
+		{"json_name", "xs", true},
+		{"(my.opt)", "5", false},
+	} {
+		o := f.Options[i]
+		if got, want := o.Name, each.name; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := o.Constant.Source, each.source; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := o.Constant.IsString, each.isString; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := o.IsEmbedded, true; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+	}
+	// reconstruct the source from the parsed options
+	list := []string{}
+	for _, each := range f.Options {
+		list = append(list, each.Name+" = "+each.Constant.SourceRepresentation())
+	}
+	if got, want := strings.Join(list, ", "), `packed = true, json_name = "xs", (my.opt) = 5`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestFieldIsImportedType(t *testing.T) {
+	def, table := loadSymbolTable(t, `
+package acme;
+import "money.proto";
+message Local {}
+message Order {
+	string id = 1;
+	Local local = 2;
+	finance.Money total = 3;
+	Unknown unknown = 4;
+}`, map[string]string{
+		"money.proto": `package acme.finance; message Money {}`,
+	})
+	order := collect(def).Messages()[1]
+	for i, each := range []bool{false, false, true, true} {
+		f := order.Elements[i].(*NormalField)
+		if got, want := f.IsImportedType(table), each; got != want {
+			t.Errorf("[%s] got [%v] want [%v]", f.Name, got, want)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FlattenNestedTypes returns a copy of the Proto in which all nested messages and enums are moved to file scope.
+// A moved type is named after its enclosing types joined by underscores, e.g. Outer.Inner becomes Outer_Inner.
+// If that name is already used at file scope, a number is appended, e.g. Outer_Inner_2, in order of declaration.
+// Moved types are placed after the top-level type that encloses them. Type references to moved types are updated.
+// Groups and extend blocks stay where they are ; types nested in groups are moved too.
+// The Proto itself is not changed.
+func FlattenNestedTypes(p *Proto) *Proto {
+	table := NewSymbolTable(nil)
+	table.Load(p)
+	clones := map[Visitee]Visitee{}
+	flat := &Proto{Filename: p.Filename}
+	flat.Elements = cloneElements(flat, p.Elements, clones)
+	// assign new names in order of declaration
+	taken := map[string]bool{}
+	for _, each := range p.Elements {
+		switch e := each.(type) {
+		case *Message:
+			taken[e.Name] = true
+		case *Enum:
+			taken[e.Name] = true
+		case *Service:
+			taken[e.Name] = true
+		}
+	}
+	names := map[Visitee]string{}
+	walk(p, func(v Visitee) {
+		if !isNestedType(v) {
+			return
+		}
+		name := qualifiedName(v)
+		if pkg := p.packageName(); len(pkg) > 0 {
+			name = strings.TrimPrefix(name, pkg+".")
+		}
+		name = strings.Replace(name, ".", "_", -1)
+		candidate := name
+		for n := 2; taken[candidate]; n++ {
+			candidate = name + "_" + strconv.Itoa(n)
+		}
+		taken[candidate] = true
+		names[v] = candidate
+	})
+	// update references in the copy
+	rename := func(typeName string, scope Visitee) string {
+		if s, ok := table.Lookup(typeName, scope); ok {
+			if name, ok := names[s.Type]; ok {
+				return name
+			}
+		}
+		return typeName
+	}
+	walk(p, func(v Visitee) {
+		switch e := v.(type) {
+		case *NormalField:
+			clones[e].(*NormalField).Type = rename(e.Type, e.Parent)
+		case *MapField:
+			clones[e].(*MapField).Type = rename(e.Type, e.Parent)
+		case *OneOfField:
+			clones[e].(*OneOfField).Type = rename(e.Type, e.Parent)
+		case *RPC:
+			rpc := clones[e].(*RPC)
+			rpc.RequestType = rename(e.RequestType, e.Parent)
+			rpc.ReturnsType = rename(e.ReturnsType, e.Parent)
+		case *Message:
+			if e.IsExtend {
+				clones[e].(*Message).Name = rename(e.Name, e.Parent)
+			}
+		}
+	})
+	for original, name := range names {
+		switch e := clones[original].(type) {
+		case *Message:
+			e.Name = name
+		case *Enum:
+			e.Name = name
+		}
+	}
+	// move the nested types
+	elements := []Visitee{}
+	for _, each := range flat.Elements {
+		elements = append(elements, each)
+		if c, ok := each.(elementContainer); ok {
+			elements = append(elements, hoistNestedTypes(c)...)
+		}
+	}
+	for _, each := range elements {
+		each.parent(flat)
+	}
+	flat.Elements = elements
+	return flat
+}
+
+// isNestedType returns true for a message or enum definition that is not declared at file scope.
+func isNestedType(v Visitee) bool {
+	switch e := v.(type) {
+	case *Message:
+		_, atFileScope := e.Parent.(*Proto)
+		return !e.IsExtend && !atFileScope
+	case *Enum:
+		_, atFileScope := e.Parent.(*Proto)
+		return !atFileScope
+	}
+	return false
+}
+
+// hoistNestedTypes removes the nested types from the container, recursively, and returns them in order of declaration.
+func hoistNestedTypes(c elementContainer) (list []Visitee) {
+	var kept *[]Visitee
+	switch e := c.(type) {
+	case *Message:
+		kept = &e.Elements
+	case *Group:
+		kept = &e.Elements
+	default:
+		return
+	}
+	remaining := []Visitee{}
+	for _, each := range *kept {
+		if isNestedType(each) {
+			list = append(list, each)
+			if next, ok := each.(elementContainer); ok {
+				list = append(list, hoistNestedTypes(next)...)
+			}
+			continue
+		}
+		remaining = append(remaining, each)
+		if g, ok := each.(*Group); ok {
+			list = append(list, hoistNestedTypes(g)...)
+		}
+	}
+	*kept = remaining
+	return
+}
+
+// cloneElements returns copies of the elements with their parent set.
+// Each copy is registered in clones by its original.
+func cloneElements(parent Visitee, elements []Visitee, clones map[Visitee]Visitee) (list []Visitee) {
+	for _, each := range elements {
+		c := cloneElement(each, clones)
+		c.parent(parent)
+		clones[each] = c
+		list = append(list, c)
+	}
+	return
+}
+
+// cloneElement returns a copy of the element with copies of all its nested elements.
+func cloneElement(v Visitee, clones map[Visitee]Visitee) Visitee {
+	switch e := v.(type) {
+	case *Message:
+		c := *e
+		c.Elements = cloneElements(&c, e.Elements, clones)
+		return &c
+	case *Group:
+		c := *e
+		c.Elements = cloneElements(&c, e.Elements, clones)
+		return &c
+	case *Enum:
+		c := *e
+		c.Elements = cloneElements(&c, e.Elements, clones)
+		return &c
+	case *EnumField:
+		c := *e
+		c.Elements = cloneElements(&c, e.Elements, clones)
+		if e.ValueOption != nil {
+			if o, ok := clones[e.ValueOption].(*Option); ok {
+				c.ValueOption = o
+			}
+		}
+		return &c
+	case *Oneof:
+		c := *e
+		c.Elements = cloneElements(&c, e.Elements, clones)
+		return &c
+	case *Service:
+		c := *e
+		c.Elements = cloneElements(&c, e.Elements, clones)
+		return &c
+	case *RPC:
+		c := *e
+		c.Elements = cloneElements(&c, e.Elements, clones)
+		c.Options = nil
+		for _, each := range c.Elements {
+			if o, ok := each.(*Option); ok {
+				c.Options = append(c.Options, o)
+			}
+		}
+		return &c
+	case *NormalField:
+		c := *e
+		c.Field = cloneField(e.Field, &c)
+		return &c
+	case *MapField:
+		c := *e
+		c.Field = cloneField(e.Field, &c)
+		return &c
+	case *OneOfField:
+		c := *e
+		c.Field = cloneField(e.Field, &c)
+		return &c
+	case *Option:
+		c := *e
+		return &c
+	case *Syntax:
+		c := *e
+		return &c
+	case *Package:
+		c := *e
+		return &c
+	case *Import:
+		c := *e
+		return &c
+	case *Reserved:
+		c := *e
+		return &c
+	case *Extensions:
+		c := *e
+		return &c
+	}
+	// Comment has no parent and is shared
+	return v
+}
+
+// cloneField returns a copy of the field with copies of its options, which get the owner as parent.
+func cloneField(f *Field, owner Visitee) *Field {
+	c := *f
+	c.Options = nil
+	for _, each := range f.Options {
+		o := *each
+		o.Parent = owner
+		c.Options = append(c.Options, &o)
+	}
+	return &c
+}
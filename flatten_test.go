@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenNestedTypes(t *testing.T) {
+	def, err := newParserOn(`package acme;
+// order doc
+message Order {
+	// item doc
+	message Item {
+		enum Kind { NONE = 0; }
+		Kind kind = 1;
+	}
+	repeated Item items = 1;
+	map<string, Item> byName = 2;
+	oneof choice {
+		Item.Kind kind = 3;
+	}
+}
+message Order_Item {}
+message Invoice {
+	Order.Item item = 1;
+	.acme.Order.Item.Kind kind = 2;
+	Order_Item other = 3;
+}
+service Orders {
+	rpc Get(Order.Item) returns (Order);
+}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	flat := FlattenNestedTypes(def)
+	var names []string
+	for _, each := range flat.Elements {
+		switch e := each.(type) {
+		case *Message:
+			names = append(names, e.Name)
+		case *Enum:
+			names = append(names, e.Name)
+		}
+		if got, want := getParent(each), Visitee(flat); got != want {
+			t.Errorf("got [%v] want [%v]", got, want)
+		}
+	}
+	if got, want := names, []string{"Order", "Order_Item_2", "Order_Item_Kind", "Order_Item", "Invoice"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	order := flat.Elements[1].(*Message)
+	if got, want := len(order.Elements), 3; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	item := flat.Elements[2].(*Message)
+	if got, want := item.Comment.Message(), " item doc"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	var types []string
+	Walk(flat, func(v Visitee) {
+		switch e := v.(type) {
+		case *NormalField:
+			types = append(types, e.Type)
+		case *MapField:
+			types = append(types, e.Type)
+		case *OneOfField:
+			types = append(types, e.Type)
+		case *RPC:
+			types = append(types, e.RequestType, e.ReturnsType)
+		}
+	})
+	want := []string{
+		"Order_Item_2", "Order_Item_2", "Order_Item_Kind", // Order
+		"Order_Item_Kind",                               // Order_Item_2
+		"Order_Item_2", "Order_Item_Kind", "Order_Item", // Invoice
+		"Order_Item_2", "Order", // Orders
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("got [%v] want [%v]", types, want)
+	}
+	checkParent(flat, t)
+	// original is unchanged
+	if got, want := len(def.Elements[1].(*Message).Elements), 4; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := def.Elements[1].(*Message).Elements[1].(*NormalField).Type, "Item"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
@@ -64,3 +64,49 @@ func TestGroup(t *testing.T) {
 		t.Errorf("got [%v] want [%v]", got, want)
 	}
 }
+
+func TestGroupInExtend(t *testing.T) {
+	src := `syntax = "proto2";
+	extend Foo {
+		// doc
+		repeated group Result = 100 {
+			required string url = 1;
+		}
+	}`
+	p := newParserOn(src)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := def.Elements[1].(*Message)
+	if got, want := e.IsExtend, true; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := len(e.Elements), 1; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	g := e.Elements[0].(*Group)
+	if got, want := g.Name, "Result"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := g.Repeated, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := g.Sequence, 100; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := g.Comment.Message(), " doc"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := g.Parent, Visitee(e); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	f := g.Elements[0].(*NormalField)
+	if got, want := f.Name, "url"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := f.Required, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	checkParent(def, t)
+}
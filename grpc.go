@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GRPCMethod summarizes an RPC as it is exposed by a gRPC server.
+type GRPCMethod struct {
+	// FullMethod is the path used on the wire, e.g. /package.Service/Method
+	FullMethod     string
+	RequestType    string
+	StreamsRequest bool
+	ReturnsType    string
+	StreamsReturns bool
+}
+
+// GRPCMethods returns a GRPCMethod for each RPC of the service.
+// The package is taken from the Proto that encloses the service.
+// Request and returns types are as written in the definition.
+func GRPCMethods(s *Service) (list []GRPCMethod) {
+	serviceName := s.Name
+	if p := RootProto(s); p != nil {
+		if pkg := p.packageName(); len(pkg) > 0 {
+			serviceName = pkg + "." + serviceName
+		}
+	}
+	for _, each := range s.Elements {
+		if rpc, ok := each.(*RPC); ok {
+			list = append(list, GRPCMethod{
+				FullMethod:     "/" + serviceName + "/" + rpc.Name,
+				RequestType:    rpc.RequestType,
+				StreamsRequest: rpc.StreamsRequest,
+				ReturnsType:    rpc.ReturnsType,
+				StreamsReturns: rpc.StreamsReturns,
+			})
+		}
+	}
+	return
+}
+
+// GenerateServiceInterfaces writes a Go interface for each service of the Proto with a method for each RPC.
+// The interface is named after the service with suffix Server ; each method takes a context.Context.
+// Streams are functions: a streamed request is read with recv and a streamed response is written with send, e.g.
+//
+//	Watch(ctx context.Context, req *WatchRequest, send func(*Event) error) error
+//
+// Message types are written as Go names: the package of the Proto is removed and other dots are replaced by underscores,
+// e.g. acme.Order.Item => Order_Item. Only the type declarations are written ; the caller provides the package clause
+// and the import of context.
+func GenerateServiceInterfaces(p *Proto, w io.Writer) error {
+	pkg := p.packageName()
+	goType := func(typeName string) string {
+		name := strings.TrimPrefix(typeName, ".")
+		if len(pkg) > 0 {
+			name = strings.TrimPrefix(name, pkg+".")
+		}
+		return "*" + strings.Replace(name, ".", "_", -1)
+	}
+	var err error
+	printf := func(format string, args ...interface{}) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+	first := true
+	for _, each := range p.Elements {
+		s, ok := each.(*Service)
+		if !ok {
+			continue
+		}
+		if !first {
+			printf("\n")
+		}
+		first = false
+		name := PascalCase(s.Name) + "Server"
+		printComment(printf, "", s.Comment, fmt.Sprintf("%s is the server API of service %s.", name, s.Name))
+		printf("type %s interface {\n", name)
+		for _, other := range s.Elements {
+			rpc, ok := other.(*RPC)
+			if !ok {
+				continue
+			}
+			printComment(printf, "\t", rpc.Comment, "")
+			params := []string{"ctx context.Context"}
+			if rpc.StreamsRequest {
+				params = append(params, fmt.Sprintf("recv func() (%s, error)", goType(rpc.RequestType)))
+			} else {
+				params = append(params, "req "+goType(rpc.RequestType))
+			}
+			results := fmt.Sprintf("(%s, error)", goType(rpc.ReturnsType))
+			if rpc.StreamsReturns {
+				params = append(params, fmt.Sprintf("send func(%s) error", goType(rpc.ReturnsType)))
+				results = "error"
+			}
+			printf("\t%s(%s) %s\n", PascalCase(rpc.Name), strings.Join(params, ", "), results)
+		}
+		printf("}\n")
+	}
+	return err
+}
+
+// printComment writes the lines of the comment as Go line comments or, if absent, the fallback line unless empty.
+func printComment(printf func(format string, args ...interface{}), indent string, c *Comment, fallback string) {
+	if c == nil {
+		if len(fallback) > 0 {
+			printf("%s// %s\n", indent, fallback)
+		}
+		return
+	}
+	for _, each := range c.Lines {
+		printf("%s//%s\n", indent, strings.TrimRight(each, " \t"))
+	}
+}
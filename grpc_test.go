@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"bytes"
+	"go/format"
+	"testing"
+)
+
+func TestGRPCMethods(t *testing.T) {
+	proto := `
+package acme.accounts.v1;
+service AccountService {
+	rpc Get(GetRequest) returns (Account);
+	rpc Watch(WatchRequest) returns (stream Account);
+}`
+	p := newParserOn(proto)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := GRPCMethods(collect(def).Services()[0])
+	if got, want := len(list), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[0].FullMethod, "/acme.accounts.v1.AccountService/Get"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[0].RequestType, "GetRequest"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[1].FullMethod, "/acme.accounts.v1.AccountService/Watch"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[1].StreamsRequest, false; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[1].StreamsReturns, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestGRPCMethodsWithoutPackage(t *testing.T) {
+	p := newParserOn(`service Echo { rpc Say(Msg) returns (Msg); }`)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := GRPCMethods(collect(def).Services()[0])
+	if got, want := list[0].FullMethod, "/Echo/Say"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestGenerateServiceInterfaces(t *testing.T) {
+	def, err := newParserOn(`package acme;
+// Orders manages orders.
+service Orders {
+	// Get returns one order.
+	rpc Get(GetRequest) returns (Order);
+	rpc Watch(WatchRequest) returns (stream Order.Event);
+	rpc upload_items(stream acme.Item) returns (google.protobuf.Empty);
+	rpc Chat(stream Message) returns (stream Message);
+}
+service health {
+	rpc Check(Ping) returns (Pong);
+}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := GenerateServiceInterfaces(def, buf); err != nil {
+		t.Fatal(err)
+	}
+	golden := `// Orders manages orders.
+type OrdersServer interface {
+	// Get returns one order.
+	Get(ctx context.Context, req *GetRequest) (*Order, error)
+	Watch(ctx context.Context, req *WatchRequest, send func(*Order_Event) error) error
+	UploadItems(ctx context.Context, recv func() (*Item, error)) (*google_protobuf_Empty, error)
+	Chat(ctx context.Context, recv func() (*Message, error), send func(*Message) error) error
+}
+
+// HealthServer is the server API of service health.
+type HealthServer interface {
+	Check(ctx context.Context, req *Ping) (*Pong, error)
+}
+`
+	if got, want := buf.String(), golden; got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	src := "package acme\n\nimport \"context\"\n\n" + buf.String()
+	if _, err := format.Source([]byte(src)); err != nil {
+		t.Errorf("invalid Go: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+)
+
+// ContentHash returns the hex encoded SHA-256 of the definitions of a Proto.
+// Included are, in order of declaration, all elements with their names, types, labels, numbers,
+// options and literal values. Excluded are comments, whitespace, positions and the filename.
+// Two definitions that differ only in comments or layout therefore have the same hash.
+// The quote used for a string literal is not included ; reordering elements changes the hash.
+func ContentHash(p *Proto) string {
+	h := &contentHasher{hash: sha256.New()}
+	p.Accept(h)
+	return hex.EncodeToString(h.hash.Sum(nil))
+}
+
+// contentHasher is a Visitor that writes a canonical text of each element to the hash.
+type contentHasher struct {
+	hash hash.Hash
+}
+
+func (c *contentHasher) write(format string, args ...interface{}) {
+	fmt.Fprintf(c.hash, format, args...)
+}
+
+func (c *contentHasher) elements(list []Visitee) {
+	c.write("{")
+	for _, each := range list {
+		each.Accept(c)
+	}
+	c.write("}")
+}
+
+func (c *contentHasher) options(list []*Option) {
+	c.write("[")
+	for _, each := range list {
+		each.Accept(c)
+	}
+	c.write("]")
+}
+
+func (c *contentHasher) literal(l *Literal) {
+	switch {
+	case l.Array != nil:
+		c.write("[")
+		for _, each := range l.Array {
+			c.literal(each)
+		}
+		c.write("]")
+	case len(l.OrderedMap) > 0:
+		c.write("{")
+		for _, each := range l.OrderedMap {
+			c.write("%q:", each.Name)
+			c.literal(each.Literal)
+		}
+		c.write("}")
+	case l.IsString:
+		c.write("%q;", l.Source)
+	default:
+		c.write("%s;", l.Source)
+	}
+}
+
+func (c *contentHasher) field(kind string, f *Field) {
+	c.write("%s %q %q %d", kind, f.Type, f.Name, f.Sequence)
+	c.options(f.Options)
+}
+
+func (c *contentHasher) VisitMessage(m *Message) {
+	c.write("%s %q", m.groupName(), m.Name)
+	c.elements(m.Elements)
+}
+func (c *contentHasher) VisitService(v *Service) {
+	c.write("service %q", v.Name)
+	c.elements(v.Elements)
+}
+func (c *contentHasher) VisitSyntax(s *Syntax) {
+	c.write("syntax %q;", s.Value)
+}
+func (c *contentHasher) VisitPackage(p *Package) {
+	c.write("package %q;", p.Name)
+}
+func (c *contentHasher) VisitOption(o *Option) {
+	c.write("option %q=", o.Name)
+	c.literal(&o.Constant)
+}
+func (c *contentHasher) VisitImport(i *Import) {
+	c.write("import %q %q;", i.Kind, i.Filename)
+}
+func (c *contentHasher) VisitNormalField(f *NormalField) {
+	c.field(fmt.Sprintf("field %t %t %t", f.Repeated, f.Optional, f.Required), f.Field)
+}
+func (c *contentHasher) VisitEnumField(f *EnumField) {
+	c.write("value %q %d", f.Name, f.Integer)
+	c.elements(f.Elements)
+}
+func (c *contentHasher) VisitEnum(e *Enum) {
+	c.write("enum %q", e.Name)
+	c.elements(e.Elements)
+}
+func (c *contentHasher) VisitComment(e *Comment) {}
+func (c *contentHasher) VisitOneof(o *Oneof) {
+	c.write("oneof %q", o.Name)
+	c.elements(o.Elements)
+}
+func (c *contentHasher) VisitOneofField(o *OneOfField) {
+	c.field("oneoffield", o.Field)
+}
+func (c *contentHasher) VisitReserved(r *Reserved) {
+	c.write("reserved %v %q;", r.Ranges, r.FieldNames)
+}
+func (c *contentHasher) VisitRPC(r *RPC) {
+	c.write("rpc %q %t %q %t %q", r.Name, r.StreamsRequest, r.RequestType, r.StreamsReturns, r.ReturnsType)
+	c.elements(r.Elements)
+}
+func (c *contentHasher) VisitMapField(f *MapField) {
+	c.field(fmt.Sprintf("map %q", f.KeyType), f.Field)
+}
+func (c *contentHasher) VisitGroup(g *Group) {
+	c.write("group %t %t %t %q %d", g.Repeated, g.Optional, g.Required, g.Name, g.Sequence)
+	c.elements(g.Elements)
+}
+func (c *contentHasher) VisitExtensions(e *Extensions) {
+	c.write("extensions %v;", e.Ranges)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import "testing"
+
+func TestContentHash(t *testing.T) {
+	hash := func(src string) string {
+		def, err := newParserOn(src).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ContentHash(def)
+	}
+	original := hash(`syntax = "proto3";
+package acme;
+// Account is documented
+message Account {
+	string id = 1 [json_name = "ID"];
+	map<string, int32> counts = 2; // inline
+	option (x) = { a: 1 };
+}`)
+	if got, want := len(original), 64; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	layout := hash(`syntax='proto3';   package acme;
+	/* other comment */
+	message Account{string id=1[json_name="ID"];map<string,int32>counts=2;
+		option (x) = {
+			a: 1
+		};
+	}`)
+	if got, want := layout, original; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	for i, each := range []string{
+		`syntax = "proto3"; package acme; message Account { string id = 1 [json_name = "Id"]; map<string, int32> counts = 2; option (x) = { a: 1 }; }`,
+		`syntax = "proto3"; package acme; message Account { string id = 3 [json_name = "ID"]; map<string, int32> counts = 2; option (x) = { a: 1 }; }`,
+		`syntax = "proto3"; package acme; message Account { map<string, int32> counts = 2; string id = 1 [json_name = "ID"]; option (x) = { a: 1 }; }`,
+		`syntax = "proto3"; package acme; message Account { string id = 1 [json_name = "ID"]; map<string, int32> counts = 2; option (x) = { a: 2 }; }`,
+	} {
+		if hash(each) == original {
+			t.Errorf("[%d] expected different hash", i)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import "strings"
+
+// HTTPRule is a mapping of an RPC onto a HTTP method and path, as annotated using the google.api.http option.
+// https://github.com/googleapis/googleapis/blob/master/google/api/http.proto
+type HTTPRule struct {
+	// Method is the uppercase HTTP method, e.g. GET or the kind of a custom pattern.
+	Method string
+	Path   string
+	Body   string
+}
+
+const googleAPIHTTPOption = "(google.api.http)"
+
+// HTTPRules returns the primary HTTP rule followed by all additional bindings of the google.api.http option.
+// The option can also be set per field, e.g. option (google.api.http).get = "/v1/a"; such options form one rule.
+// Returns an empty list if the RPC has no such option.
+func (r *RPC) HTTPRules() (list []HTTPRule) {
+	var fields LiteralMap
+	for _, each := range r.Elements {
+		o, ok := each.(*Option)
+		if !ok {
+			continue
+		}
+		if o.Name == googleAPIHTTPOption {
+			list = append(list, collectHTTPRules(o.Constant.OrderedMap)...)
+			continue
+		}
+		if parts := o.NameParts(); len(parts) > 1 && parts[0] == googleAPIHTTPOption {
+			constant := o.Constant
+			fields = append(fields, &NamedLiteral{Name: parts[len(parts)-1], Literal: &constant})
+		}
+	}
+	if len(fields) > 0 {
+		list = append(list, collectHTTPRules(fields)...)
+	}
+	return
+}
+
+// collectHTTPRules returns the rule of the map and the rules of its additional bindings.
+func collectHTTPRules(m LiteralMap) (list []HTTPRule) {
+	if rule, ok := httpRuleFrom(m); ok {
+		list = append(list, rule)
+	}
+	for _, each := range m {
+		if each.Name != "additional_bindings" {
+			continue
+		}
+		// can be repeated as a key or written as an array
+		if each.Array != nil {
+			for _, other := range each.Array {
+				list = append(list, collectHTTPRules(other.OrderedMap)...)
+			}
+		} else {
+			list = append(list, collectHTTPRules(each.OrderedMap)...)
+		}
+	}
+	return
+}
+
+// httpRuleFrom returns the rule for the pattern and body found in the map.
+func httpRuleFrom(m LiteralMap) (rule HTTPRule, ok bool) {
+	for _, each := range m {
+		switch each.Name {
+		case "get", "put", "post", "delete", "patch":
+			rule.Method = strings.ToUpper(each.Name)
+			rule.Path = each.Source
+			ok = true
+		case "custom":
+			kind, _ := each.OrderedMap.Get("kind")
+			path, _ := each.OrderedMap.Get("path")
+			rule.Method = kind.Source
+			rule.Path = path.Source
+			ok = true
+		case "body":
+			rule.Body = each.Source
+		}
+	}
+	return
+}
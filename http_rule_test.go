@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import "testing"
+
+func TestRPCHTTPRules(t *testing.T) {
+	proto := `
+service Library {
+	rpc UpdateBook(UpdateBookRequest) returns (Book) {
+		option (google.api.http) = {
+			patch: "/v1/{book.name=shelves/*/books/*}"
+			body: "book"
+			additional_bindings {
+				put: "/v1/books/{book.name}"
+				body: "*"
+			}
+		};
+	}
+	rpc Head(HeadRequest) returns (Book) {
+		option (google.api.http) = {
+			custom: { kind: "HEAD" path: "/v1/books" }
+		};
+	}
+}`
+	p := newParserOn(proto)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := collect(def).Services()[0]
+	rules := service.Elements[0].(*RPC).HTTPRules()
+	if got, want := len(rules), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	for i, each := range []HTTPRule{
+		{Method: "PATCH", Path: "/v1/{book.name=shelves/*/books/*}", Body: "book"},
+		{Method: "PUT", Path: "/v1/books/{book.name}", Body: "*"},
+	} {
+		if got, want := rules[i], each; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+	}
+	rules = service.Elements[1].(*RPC).HTTPRules()
+	if got, want := len(rules), 1; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := rules[0], (HTTPRule{Method: "HEAD", Path: "/v1/books"}); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestRPCHTTPRulesWithoutOption(t *testing.T) {
+	p := newParserOn(`service Echo { rpc Say(Msg) returns (Msg); }`)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(collect(def).Services()[0].Elements[0].(*RPC).HTTPRules()), 0; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestRPCHTTPRulesSubFieldOptions(t *testing.T) {
+	def, err := newParserOn(`service Library {
+	rpc GetBook(GetBookRequest) returns (Book) {
+		option (google.api.http).get = "/v1/a";
+	}
+	rpc CreateBook(CreateBookRequest) returns (Book) {
+		option (google.api.http).post = "/v1/books";
+		option (google.api.http).body = "book";
+		option (other.http).get = "/ignored";
+	}
+}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := collect(def).Services()[0]
+	for i, want := range []HTTPRule{
+		{Method: "GET", Path: "/v1/a"},
+		{Method: "POST", Path: "/v1/books", Body: "book"},
+	} {
+		rules := service.Elements[i].(*RPC).HTTPRules()
+		if got, want := len(rules), 1; got != want {
+			t.Fatalf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got := rules[0]; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+	}
+}
@@ -41,3 +41,40 @@ func TestParseImport(t *testing.T) {
 		t.Errorf("got [%v] want [%v]", got, want)
 	}
 }
+
+func TestImportWithWhitespaceAndInlineComment(t *testing.T) {
+	src := `import "a.proto" ; // note
+import   public   "b.proto"	;	/* other */
+import "c.proto";`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(def.Elements), 3; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	for i, each := range []struct {
+		filename, kind, comment string
+	}{
+		{"a.proto", "", " note"},
+		{"b.proto", "public", " other "},
+		{"c.proto", "", ""},
+	} {
+		im := def.Elements[i].(*Import)
+		if got, want := im.Filename, each.filename; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := im.Kind, each.kind; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := im.InlineComment != nil, len(each.comment) > 0; got != want {
+			t.Fatalf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if im.InlineComment != nil && im.InlineComment.Message() != each.comment {
+			t.Errorf("[%d] got [%v] want [%v]", i, im.InlineComment.Message(), each.comment)
+		}
+		if got, want := im.Comment, (*Comment)(nil); got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+	}
+}
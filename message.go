@@ -231,3 +231,148 @@ func (m *Message) Doc() *Comment {
 }
 
 func (m *Message) parent(v Visitee) { m.Parent = v }
+
+// Dependencies returns the fully qualified names of the message and enum types used by the fields of the message,
+// including map values and fields of nested types, in order of first use.
+// Scalar types, the message itself and types that cannot be found in the table are excluded.
+func (m *Message) Dependencies(table *SymbolTable) (list []string) {
+	self := qualifiedName(m)
+	seen := map[string]bool{self: true}
+	add := func(typeName string, scope Visitee) {
+		if isScalarType(typeName) {
+			return
+		}
+		s, ok := table.Lookup(typeName, scope)
+		if !ok || seen[s.Name] {
+			return
+		}
+		seen[s.Name] = true
+		list = append(list, s.Name)
+	}
+	walk(m, func(v Visitee) {
+		switch f := v.(type) {
+		case *NormalField:
+			add(f.Type, f.Parent)
+		case *MapField:
+			add(f.Type, f.Parent)
+		case *OneOfField:
+			add(f.Type, f.Parent)
+		}
+	})
+	return
+}
+
+// MapFields returns the map fields of the message in order of declaration ; those of nested messages are not included.
+func (m *Message) MapFields() (list []*MapField) {
+	for _, each := range m.Elements {
+		if f, ok := each.(*MapField); ok {
+			list = append(list, f)
+		}
+	}
+	return
+}
+
+// ReservedMarker is used by FieldNumberHistory for a number that is reserved in a version.
+const ReservedMarker = "(reserved)"
+
+// FieldNumberHistory returns for each field number used in the old or new version of a message
+// the names it had, oldest first. A number that is reserved in a version has the ReservedMarker as name.
+// A name is listed once if it did not change, e.g. a renamed field 3 gives [old_name new_name].
+func FieldNumberHistory(older, newer *Message) map[int][]string {
+	history := map[int][]string{}
+	versions := []*Message{older, newer}
+	fields := make([]map[int]string, len(versions))
+	for i, each := range versions {
+		fields[i] = fieldNames(each)
+		for number := range fields[i] {
+			history[number] = nil
+		}
+	}
+	for number := range history {
+		for i, each := range versions {
+			name, ok := fields[i][number]
+			if !ok {
+				if !isReservedNumber(each, number) {
+					continue
+				}
+				name = ReservedMarker
+			}
+			if names := history[number]; len(names) == 0 || names[len(names)-1] != name {
+				history[number] = append(names, name)
+			}
+		}
+	}
+	return history
+}
+
+// fieldNames returns the name by number of all fields and groups of the message, including those of oneofs.
+func fieldNames(m *Message) map[int]string {
+	names := map[int]string{}
+	for _, each := range messageFields(m) {
+		names[each.Sequence] = each.Name
+	}
+	for _, each := range m.Elements {
+		switch e := each.(type) {
+		case *Group:
+			names[e.Sequence] = e.Name
+		case *Oneof:
+			for _, other := range e.Elements {
+				if g, ok := other.(*Group); ok {
+					names[g.Sequence] = g.Name
+				}
+			}
+		}
+	}
+	return names
+}
+
+// isReservedNumber returns true if the number is in one of the reserved ranges of the message.
+func isReservedNumber(m *Message, number int) bool {
+	for _, each := range m.Elements {
+		if r, ok := each.(*Reserved); ok && inRanges(number, r.Ranges) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMapEntry returns true if the message has the option map_entry = true.
+func isMapEntry(m *Message) bool {
+	for _, each := range m.Elements {
+		if o, ok := each.(*Option); ok && o.Name == "map_entry" && o.Constant.Source == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxFieldNumber returns the largest number of the fields of the message, including those of oneofs and groups ; 0 if there are none.
+// If includeReserved is true then the upper bounds of the reserved ranges are taken into account too ; max counts as 536870911.
+// Fields of nested messages are not included.
+func (m *Message) MaxFieldNumber(includeReserved bool) int {
+	largest := 0
+	for number := range fieldNames(m) {
+		if number > largest {
+			largest = number
+		}
+	}
+	if !includeReserved {
+		return largest
+	}
+	for _, each := range m.Elements {
+		r, ok := each.(*Reserved)
+		if !ok {
+			continue
+		}
+		for _, other := range r.Ranges {
+			to := other.To
+			if other.Max {
+				to = maxFieldNumber
+			}
+			if to > largest {
+				largest = to
+			}
+		}
+	}
+	return largest
+}
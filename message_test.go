@@ -24,6 +24,7 @@
 package proto
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -175,3 +176,308 @@ func TestMessageInlineCommentBeforeBody(t *testing.T) {
 		t.Errorf("got %d want %d lines", got, want)
 	}
 }
+
+func TestExtendAtFileScope(t *testing.T) {
+	src := `// doc
+	extend google.protobuf.FieldOptions {
+		optional string label = 5000;
+	}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := def.Elements[0].(*Message)
+	if got, want := e.IsExtend, true; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := e.Name, "google.protobuf.FieldOptions"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := e.Comment.Message(), " doc"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := e.Parent, Visitee(def); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := e.Elements[0].(*NormalField).Name, "label"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	checkParent(def, t)
+}
+
+func TestExtendNestedInMessage(t *testing.T) {
+	src := `message Outer {
+		extend .Foo {
+			optional Outer outer = 100;
+		}
+		message Inner {
+			// doc
+			extend Outer {
+				repeated int32 numbers = 101 [packed = true];
+			}
+		}
+		string name = 1;
+	}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outer := def.Elements[0].(*Message)
+	if got, want := len(outer.Elements), 3; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	e := outer.Elements[0].(*Message)
+	if got, want := e.IsExtend, true; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := e.Name, ".Foo"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := e.Parent, Visitee(outer); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	inner := outer.Elements[1].(*Message)
+	nested := inner.Elements[0].(*Message)
+	if got, want := nested.IsExtend, true; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := nested.Parent, Visitee(inner); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := nested.Comment.Message(), " doc"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	f := nested.Elements[0].(*NormalField)
+	if got, want := f.Name, "numbers"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := f.Repeated, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := outer.Elements[2].(*NormalField).Name, "name"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	checkParent(def, t)
+}
+
+func TestStreamAsIdentifier(t *testing.T) {
+	src := `message Stream {
+		Stream stream = 1;
+		repeated stream.Event events = 2;
+		oneof choice {
+			stream other = 3;
+		}
+		map<string, stream> streams = 4;
+	}
+	message stream {}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := collect(def).Messages()
+	if got, want := len(messages), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	m := messages[0]
+	if got, want := m.Name, "Stream"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := m.Elements[0].(*NormalField).Name, "stream"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := m.Elements[1].(*NormalField).Type, "stream.Event"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := m.Elements[2].(*Oneof).Elements[0].(*OneOfField).Type, "stream"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := m.Elements[3].(*MapField).Type, "stream"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := messages[1].Name, "stream"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestMessageDependencies(t *testing.T) {
+	def, table := loadSymbolTable(t, `
+package acme;
+import "money.proto";
+enum Status { UNKNOWN = 0; }
+message Customer {}
+message Order {
+	string id = 1;
+	Customer customer = 2;
+	map<string, finance.Money> totals = 3;
+	Order parent = 4;
+	message Line {
+		Customer customer = 1;
+		Status status = 2;
+	}
+	oneof choice {
+		Missing missing = 5;
+	}
+}`, map[string]string{
+		"money.proto": `package acme.finance; message Money {}`,
+	})
+	order := collect(def).Messages()[1]
+	if got, want := order.Dependencies(table), []string{"acme.Customer", "acme.finance.Money", "acme.Status"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestFieldNumberHistory(t *testing.T) {
+	parse := func(src string) *Message {
+		def, err := newParserOn(src).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return collect(def).Messages()[0]
+	}
+	older := parse(`message Account {
+		string id = 1;
+		string name = 2;
+		string nick = 3;
+		int32 age = 4;
+	}`)
+	newer := parse(`message Account {
+		reserved 2;
+		string id = 1;
+		string nickname = 3;
+		oneof contact {
+			string email = 5;
+		}
+		reserved 4, 6 to 10;
+	}`)
+	history := FieldNumberHistory(older, newer)
+	for number, want := range map[int][]string{
+		1: {"id"},
+		2: {"name", ReservedMarker},
+		3: {"nick", "nickname"},
+		4: {"age", ReservedMarker},
+		5: {"email"},
+	} {
+		if got := history[number]; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%d] got [%v] want [%v]", number, got, want)
+		}
+	}
+	if got, want := len(history), 5; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestDeeplyNestedMessage(t *testing.T) {
+	src := `message A {
+		message B {
+			message C {
+				message D {
+					enum E {
+						E0 = 0;
+					}
+					oneof choice {
+						string d = 1;
+						group G = 2 {
+							optional int32 g = 3;
+						}
+					}
+				}
+				D d = 1;
+			}
+			C c = 1;
+		}
+		B b = 1;
+	}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkParent(def, t)
+	depth := map[string]int{}
+	Walk(def, func(v Visitee) {
+		var name string
+		switch e := v.(type) {
+		case *Message:
+			name = e.Name
+		case *Enum:
+			name = e.Name
+		case *Oneof:
+			name = e.Name
+		case *Group:
+			name = e.Name
+		default:
+			return
+		}
+		n := 0
+		for p := getParent(v); p != def; p = getParent(p) {
+			n++
+		}
+		depth[name] = n
+	})
+	for name, want := range map[string]int{"A": 0, "B": 1, "C": 2, "D": 3, "E": 4, "choice": 4, "G": 5} {
+		if got := depth[name]; got != want {
+			t.Errorf("%s: got [%v] want [%v]", name, got, want)
+		}
+	}
+}
+
+func TestMessageMaxFieldNumber(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto2";
+	message M {
+		optional string a = 3;
+		oneof choice {
+			string b = 12;
+			group G = 7 {
+				optional int32 c = 40;
+			}
+		}
+		map<string, int32> d = 5;
+		reserved 20 to 30;
+		message Nested { optional int32 e = 99; }
+	}
+	message Empty {
+		reserved 10 to max;
+	}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := def.Elements[1].(*Message)
+	if got, want := m.MaxFieldNumber(false), 12; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := m.MaxFieldNumber(true), 30; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	empty := def.Elements[2].(*Message)
+	if got, want := empty.MaxFieldNumber(false), 0; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := empty.MaxFieldNumber(true), 536870911; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestMessageMapFields(t *testing.T) {
+	def, err := newParserOn(`message M {
+		map<string, int32> counts = 1;
+		string name = 2;
+		map<int64, Item> items = 3;
+		message Item {
+			map<string, string> labels = 1;
+		}
+	}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := def.Elements[0].(*Message).MapFields()
+	if got, want := len(list), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	for i, each := range []struct{ name, key, value string }{
+		{"counts", "string", "int32"},
+		{"items", "int64", "Item"},
+	} {
+		if got, want := list[i].Name+" "+list[i].KeyType+" "+list[i].Type, each.name+" "+each.key+" "+each.value; got != want {
+			t.Errorf("got [%v] want [%v]", got, want)
+		}
+	}
+}
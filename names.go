@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import "strings"
+
+// CamelCase returns the name as protoc converts a field name into its default JSON name.
+// Each underscore is removed and the character that follows it is uppercased ; all other characters are kept as is.
+// Examples: foo_bar_2 => fooBar2, _foo => Foo, foo__bar => fooBar, FOO_BAR => FOOBAR
+func CamelCase(name string) string {
+	return underscoresToCamelCase(name, false)
+}
+
+// PascalCase returns the name as protoc converts it into a camel case name with an uppercase first character.
+// Examples: foo_bar_2 => FooBar2, _foo => Foo, foo2bar => Foo2bar
+func PascalCase(name string) string {
+	return underscoresToCamelCase(name, true)
+}
+
+// underscoresToCamelCase follows ToJsonName and ToCamelCase of protoc (descriptor.cc).
+// Only ASCII letters are changed, like protoc does.
+func underscoresToCamelCase(name string, capitalizeFirst bool) string {
+	var b strings.Builder
+	capitalizeNext := capitalizeFirst
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c == '_' {
+			capitalizeNext = true
+			continue
+		}
+		if capitalizeNext && 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		capitalizeNext = false
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import "testing"
+
+func TestCamelCaseAndPascalCase(t *testing.T) {
+	for _, each := range []struct {
+		name, camel, pascal string
+	}{
+		{"foo", "foo", "Foo"},
+		{"foo_bar", "fooBar", "FooBar"},
+		{"foo_bar_2", "fooBar2", "FooBar2"},
+		{"foo_2bar", "foo2bar", "Foo2bar"},
+		{"_foo", "Foo", "Foo"},
+		{"foo_", "foo", "Foo"},
+		{"foo__bar", "fooBar", "FooBar"},
+		{"fooBar", "fooBar", "FooBar"},
+		{"FOO_BAR", "FOOBAR", "FOOBAR"},
+		{"", "", ""},
+	} {
+		if got, want := CamelCase(each.name), each.camel; got != want {
+			t.Errorf("[%s] got [%v] want [%v]", each.name, got, want)
+		}
+		if got, want := PascalCase(each.name), each.pascal; got != want {
+			t.Errorf("[%s] got [%v] want [%v]", each.name, got, want)
+		}
+	}
+}
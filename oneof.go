@@ -76,7 +76,7 @@ func (o *Oneof) parse(p *Parser) error {
 			if com := mergeOrReturnComment(o.elements(), lit, pos); com != nil { // not merged?
 				o.addElement(com)
 			}
-		case tIDENT:
+		case tIDENT, tSTREAM:
 			f := newOneOfField()
 			f.Position = pos
 			f.Comment, o.Elements = takeLastCommentIfEndsOnLine(o.elements(), pos.Line-1) // TODO call takeLastComment instead?
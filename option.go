@@ -27,6 +27,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strings"
 	"text/scanner"
 )
 
@@ -41,12 +42,15 @@ type Option struct {
 	AggregatedConstants []*NamedLiteral
 	InlineComment       *Comment
 	Parent              Visitee
+	// rawValue is the source text of the value
+	rawValue string
 }
 
 // parse reads an Option body
-// ( ident | "(" fullIdent ")" ) { "." ident } "=" constant ";"
+// ( ident | "(" fullIdent ")" ) { "." ( ident | "(" fullIdent ")" ) } "=" constant ";"
 func (o *Option) parse(p *Parser) error {
 	pos, tok, lit := p.nextIdentifier()
+	namePos := pos
 	if tLEFTPAREN == tok {
 		pos, tok, lit = p.nextIdentifier()
 		if tok != tIDENT {
@@ -78,20 +82,51 @@ func (o *Option) parse(p *Parser) error {
 		o.Name = lit
 	}
 	pos, tok, lit = p.next()
-	if tDOT == tok {
-		// extend identifier
-		pos, tok, lit = p.nextIdent(true) // keyword allowed as start
-		if tok != tIDENT {
-			if !isKeyword(tok) {
-				return p.unexpected(lit, "option postfix identifier", o)
+	// a dot before ( can have been consumed already by nextIdent
+	for tDOT == tok || tLEFTPAREN == tok {
+		if tDOT == tok && p.peekNonWhitespace() != '(' {
+			// extend identifier
+			pos, tok, lit = p.nextIdent(true) // keyword allowed as start
+			if tok != tIDENT {
+				if !isKeyword(tok) {
+					return p.unexpected(lit, "option postfix identifier", o)
+				}
+			}
+			o.Name = fmt.Sprintf("%s.%s", o.Name, lit)
+		} else {
+			if tDOT == tok {
+				p.next() // consume (
 			}
+			pos, tok, lit = p.nextIdentifier()
+			if tok != tIDENT {
+				if !isKeyword(tok) {
+					return p.unexpected(lit, "option postfix full identifier", o)
+				}
+			}
+			pos, tok, _ = p.next()
+			if tok != tRIGHTPAREN {
+				return p.unexpected(lit, "option postfix full identifier closing )", o)
+			}
+			o.Name = fmt.Sprintf("%s.(%s)", o.Name, lit)
 		}
-		o.Name = fmt.Sprintf("%s.%s", o.Name, lit)
 		pos, tok, lit = p.next()
 	}
+	if p.OnUnknownOption != nil && !isBuiltinOption(o.Name) {
+		p.OnUnknownOption(o.Name, namePos)
+	}
 	if tEQUALS != tok {
+		if p.AllowBareBoolOptions && (tCOMMA == tok || tRIGHTSQUARE == tok || tSEMICOLON == tok) {
+			// put back the separator or terminator for the caller
+			p.nextPut(pos, tok, lit)
+			o.Constant = Literal{Position: pos, Source: "true"}
+			return nil
+		}
 		return p.unexpected(lit, "option value assignment =", o)
 	}
+	start := pos.Offset + 1 // after =
+	// keep the source text of the value up to the terminator
+	p.source.recording = true
+	defer func() { p.source.recording = false }()
 	r := p.peekNonWhitespace()
 	var err error
 	// values of an option can have illegal escape sequences
@@ -111,7 +146,74 @@ func (o *Option) parse(p *Parser) error {
 			o.Constant = *l
 		}
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	// the next token, such as ; or ], ends the value
+	pos, tok, lit = p.next()
+	p.nextPut(pos, tok, lit)
+	o.rawValue = p.sourceBetween(start, pos.Offset)
+	return nil
+}
+
+// RawValue returns the source text of the value, e.g. { a: 1 } for an aggregate.
+// Use it to inspect or preserve values with a structure that is not fully represented by Constant.
+// Returns empty for an Option that was not parsed or a bare bool option.
+func (o *Option) RawValue() string {
+	return o.rawValue
+}
+
+// NameParts returns the dot separated parts of the name ; a parenthesized extension name is one part.
+// Examples: java_package.subkey => [java_package subkey], (my.ext).field => [(my.ext) field]
+func (o *Option) NameParts() (list []string) {
+	start, depth := 0, 0
+	for i, each := range o.Name {
+		switch each {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '.':
+			if depth == 0 {
+				list = append(list, o.Name[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(list, o.Name[start:])
+}
+
+// builtinOptions has the names of all options defined in google/protobuf/descriptor.proto
+// and the field pseudo-options default and json_name.
+var builtinOptions = map[string]bool{
+	// file
+	"java_package": true, "java_outer_classname": true, "java_multiple_files": true,
+	"java_generate_equals_and_hash": true, "java_string_check_utf8": true, "optimize_for": true,
+	"go_package": true, "cc_generic_services": true, "java_generic_services": true,
+	"py_generic_services": true, "php_generic_services": true, "cc_enable_arenas": true,
+	"objc_class_prefix": true, "csharp_namespace": true, "swift_prefix": true,
+	"php_class_prefix": true, "php_namespace": true, "php_metadata_namespace": true, "ruby_package": true,
+	// message
+	"message_set_wire_format": true, "no_standard_descriptor_accessor": true, "map_entry": true,
+	"deprecated_legacy_json_field_conflicts": true,
+	// field
+	"ctype": true, "packed": true, "jstype": true, "lazy": true, "unverified_lazy": true,
+	"weak": true, "debug_redact": true, "retention": true, "targets": true, "edition_defaults": true,
+	"feature_support": true, "default": true, "json_name": true,
+	// enum
+	"allow_alias": true,
+	// method
+	"idempotency_level": true,
+	// all
+	"deprecated": true, "features": true, "uninterpreted_option": true,
+}
+
+// isBuiltinOption returns true if the name, or its first part before a dot, is a builtin option.
+func isBuiltinOption(name string) bool {
+	if dot := strings.Index(name, "."); dot != -1 && !strings.HasPrefix(name, "(") {
+		name = name[:dot]
+	}
+	return builtinOptions[name]
 }
 
 // inlineComment is part of commentInliner.
@@ -168,8 +270,34 @@ func (m LiteralMap) Get(key string) (*Literal, bool) {
 }
 
 // SourceRepresentation returns the source (use the same rune that was used to delimit the string).
+// Array and aggregate values, including arrays of aggregates, are written on a single line.
 func (l Literal) SourceRepresentation() string {
 	var buf bytes.Buffer
+	if l.Array != nil {
+		buf.WriteRune('[')
+		for i, each := range l.Array {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(each.SourceRepresentation())
+		}
+		buf.WriteRune(']')
+		return buf.String()
+	}
+	if len(l.OrderedMap) > 0 {
+		buf.WriteRune('{')
+		for _, each := range l.OrderedMap {
+			buf.WriteRune(' ')
+			buf.WriteString(each.Name)
+			if each.PrintsColon {
+				buf.WriteRune(':')
+			}
+			buf.WriteRune(' ')
+			buf.WriteString(each.Literal.SourceRepresentation())
+		}
+		buf.WriteString(" }")
+		return buf.String()
+	}
 	if l.IsString {
 		if l.QuoteRune == emptyRune {
 			buf.WriteRune('"')
@@ -230,7 +358,7 @@ func (l *Literal) parse(p *Parser) error {
 		l.Position, l.Source, l.IsString = pos, "", false
 		constants, err := parseAggregateConstants(p, l)
 		if err != nil {
-			return nil
+			return err
 		}
 		l.OrderedMap = LiteralMap(constants)
 		return nil
@@ -244,6 +372,21 @@ func (l *Literal) parse(p *Parser) error {
 		l.Position, l.Source = pos, "-"+l.Source
 		return nil
 	}
+	if tDOT == tok || isIdentifierStart(lit) {
+		// identifier such as an enum constant, can be qualified e.g. my.pkg.MyEnum.VALUE
+		source := lit
+		if tDOT == tok {
+			_, _, lit = p.next()
+			source = "." + lit
+		}
+		for '.' == p.peekNonWhitespace() {
+			p.next() // consume dot
+			_, _, lit := p.next()
+			source += "." + lit
+		}
+		l.Position, l.Source, l.IsString = pos, source, false
+		return nil
+	}
 	source := lit
 	iss := isString(lit)
 	if iss {
@@ -402,3 +545,63 @@ func parseAggregateConstants(p *Parser, container interface{}) (list []*NamedLit
 }
 
 func (o *Option) parent(v Visitee) { o.Parent = v }
+
+// EffectiveOptions returns the options of the element by name, including those it inherits from its enclosing elements.
+// These rules are applied, from the Proto down to the element, so the nearest declaration wins:
+//   - options declared by the element itself are included ;
+//   - features options (editions), e.g. features.field_presence, are inherited from all enclosing elements, including the file ;
+//   - the option deprecated = true is inherited from all enclosing elements, including the file ; see EffectiveDeprecated.
+//
+// An aggregate features option, e.g. option features = { field_presence: EXPLICIT }, is included per feature as features.field_presence.
+func EffectiveOptions(v Visitee) map[string]*Literal {
+	chain := []Visitee{}
+	for each := v; each != nil; {
+		chain = append([]Visitee{each}, chain...)
+		if _, ok := each.(*Proto); ok {
+			break
+		}
+		each = getParent(each)
+	}
+	effective := map[string]*Literal{}
+	for i, each := range chain {
+		own := i == len(chain)-1
+		for _, o := range optionsOf(each) {
+			inherited := o.Name == "features" || strings.HasPrefix(o.Name, "features.") || isDeprecatedOption(o)
+			if !own && !inherited {
+				continue
+			}
+			if o.Name == "features" && len(o.Constant.OrderedMap) > 0 {
+				for _, feature := range o.Constant.OrderedMap {
+					effective["features."+feature.Name] = feature.Literal
+				}
+				continue
+			}
+			literal := o.Constant
+			effective[o.Name] = &literal
+		}
+	}
+	return effective
+}
+
+// optionsOf returns the options declared by the element.
+func optionsOf(v Visitee) (list []*Option) {
+	var elements []Visitee
+	switch e := v.(type) {
+	case *NormalField:
+		return e.Options
+	case *MapField:
+		return e.Options
+	case *OneOfField:
+		return e.Options
+	case *EnumField:
+		elements = e.Elements
+	case elementContainer:
+		elements = e.elements()
+	}
+	for _, each := range elements {
+		if o, ok := each.(*Option); ok {
+			list = append(list, o)
+		}
+	}
+	return
+}
@@ -24,7 +24,11 @@
 package proto
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"text/scanner"
 )
 
 func TestOptionCases(t *testing.T) {
@@ -730,3 +734,359 @@ func TestLiteralNoQuoteRuneSet(t *testing.T) {
 		t.Errorf("got [%s] want [%s]", got, want)
 	}
 }
+
+func TestOptionWithArrayOfAggregates(t *testing.T) {
+	src := `option (x) = { items: [{ a: 1 }, { a: 2 }] };`
+	p := newParserOn(src)
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := def.Elements[0].(*Option)
+	items, ok := o.Constant.OrderedMap.Get("items")
+	if !ok {
+		t.Fatal("expected items")
+	}
+	if got, want := len(items.Array), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	for i, each := range []string{"1", "2"} {
+		a, ok := items.Array[i].OrderedMap.Get("a")
+		if !ok {
+			t.Fatalf("[%d] expected a", i)
+		}
+		if got, want := a.Source, each; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+	}
+	sr := o.Constant.SourceRepresentation()
+	if got, want := sr, `{ items: [{ a: 1 }, { a: 2 }] }`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	// parse again
+	def, err = newParserOn("option (x) = " + sr + ";").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := def.Elements[0].(*Option).Constant.SourceRepresentation(), sr; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestOptionWithInvalidArrayOfAggregates(t *testing.T) {
+	_, err := newParserOn(`option (x) = { items: [{ a: 1 }, { a: ] };`).Parse()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestOptionBareBool(t *testing.T) {
+	src := `message M {
+		option deprecated;
+		string a = 1 [deprecated, json_name = "A"];
+		string b = 2 [(my.flag)];
+	}`
+	p := newParserOn(src)
+	p.AllowBareBoolOptions = true
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := def.Elements[0].(*Message)
+	o := m.Elements[0].(*Option)
+	if got, want := o.Name, "deprecated"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := o.Constant.Source, "true"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	a := m.Elements[1].(*NormalField)
+	if got, want := len(a.Options), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := a.Options[0].Constant.Source, "true"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := a.Options[1].Constant.Source, "A"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	b := m.Elements[2].(*NormalField)
+	if got, want := b.Options[0].Name, "(my.flag)"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := b.Options[0].Constant.Source, "true"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestOptionBareBoolNotAllowed(t *testing.T) {
+	for _, each := range []string{
+		`message M { string a = 1 [deprecated]; }`,
+		`message M { option deprecated; }`,
+	} {
+		if _, err := newParserOn(each).Parse(); err == nil {
+			t.Errorf("expected error for %s", each)
+		}
+	}
+}
+
+func TestOptionStringWithWhitespace(t *testing.T) {
+	for i, each := range []struct {
+		src       string
+		source    string
+		quoteRune rune
+	}{
+		{"option (x) = \"a\tb\";", "a\tb", '"'},
+		{"option (x) = \"a\\tb  c\";", `a\tb  c`, '"'},
+		{"option (x) = 'a\tb  c';", "a\tb  c", '\''},
+		{"option (x) = 'it\\'s \"quoted\"';", `it\'s "quoted"`, '\''},
+	} {
+		def, err := newParserOn(each.src).Parse()
+		if err != nil {
+			t.Fatal(i, err)
+		}
+		o := def.Elements[0].(*Option)
+		if got, want := o.Constant.Source, each.source; got != want {
+			t.Errorf("[%d] got [%q] want [%q]", i, got, want)
+		}
+		if got, want := o.Constant.QuoteRune, each.quoteRune; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		// source representation reproduces the literal as written
+		if got, want := "option (x) = "+o.Constant.SourceRepresentation()+";", each.src; got != want {
+			t.Errorf("[%d] got [%q] want [%q]", i, got, want)
+		}
+	}
+}
+
+func TestSingleQuotedStringInAggregate(t *testing.T) {
+	def, err := newParserOn("option (x) = { name: 'first  second' id: 1 };").Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, _ := def.Elements[0].(*Option).Constant.OrderedMap.Get("name")
+	if got, want := name.Source, "first  second"; got != want {
+		t.Errorf("got [%q] want [%q]", got, want)
+	}
+}
+
+func TestOnUnknownOption(t *testing.T) {
+	src := `option java_package = "com.example";
+option (my.file) = true;
+message M {
+	option deprecated = true;
+	string a = 1 [json_name = "A", (my.field).value = 2, packd = true];
+	option features.field_presence = EXPLICIT;
+}`
+	p := newParserOn(src)
+	found := []string{}
+	p.OnUnknownOption = func(name string, pos scanner.Position) {
+		found = append(found, fmt.Sprintf("%s@%d:%d", name, pos.Line, pos.Column))
+	}
+	if _, err := p.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(found, " "), "(my.file)@2:8 (my.field).value@5:33 packd@5:55"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestOptionDottedNames(t *testing.T) {
+	for i, each := range []struct {
+		src   string
+		name  string
+		parts []string
+	}{
+		{`option java_package.subkey = "x";`, "java_package.subkey", []string{"java_package", "subkey"}},
+		{`option a.b.c = 1;`, "a.b.c", []string{"a", "b", "c"}},
+		{`option (a.b).c.d = 1;`, "(a.b).c.d", []string{"(a.b)", "c", "d"}},
+		{`option features.(pb.cpp).legacy_closed_enum = true;`, "features.(pb.cpp).legacy_closed_enum", []string{"features", "(pb.cpp)", "legacy_closed_enum"}},
+		{`option (a).(b.c) = 1;`, "(a).(b.c)", []string{"(a)", "(b.c)"}},
+		{`option deprecated = true;`, "deprecated", []string{"deprecated"}},
+	} {
+		def, err := newParserOn(each.src).Parse()
+		if err != nil {
+			t.Fatal(i, err)
+		}
+		o := def.Elements[0].(*Option)
+		if got, want := o.Name, each.name; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := o.NameParts(), each.parts; !reflect.DeepEqual(got, want) {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+	}
+}
+
+func TestOptionAtEOFWithoutNewline(t *testing.T) {
+	for _, each := range []struct {
+		src, name, value string
+	}{
+		{`syntax = "proto3"; option go_package = "x";`, "go_package", `"x"`},
+		{`option (my.opt) = { a: 1 };`, "(my.opt)", "{ a: 1 }"},
+		{`option deprecated = true; // last`, "deprecated", "true"},
+	} {
+		def, err := newParserOn(each.src).Parse()
+		if err != nil {
+			t.Fatalf("%s: %v", each.src, err)
+		}
+		o, ok := def.Elements[len(def.Elements)-1].(*Option)
+		if !ok {
+			t.Fatalf("%s: got [%T] want [*Option]", each.src, def.Elements[len(def.Elements)-1])
+		}
+		if got, want := o.Name, each.name; got != want {
+			t.Errorf("got [%v] want [%v]", got, want)
+		}
+		if got, want := o.Constant.SourceRepresentation(), each.value; got != want {
+			t.Errorf("got [%v] want [%v]", got, want)
+		}
+	}
+}
+
+func TestOptionRawValue(t *testing.T) {
+	src := `option (complex) = {
+		a: [1, 2]
+		b { c: "d" }
+	};
+	option go_package = "x" ;
+	message M {
+		string s = 1 [(my.rule) = { min: -1 }, deprecated = true];
+	}
+	option last = 'end'`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	Walk(def, WithOption(func(o *Option) {
+		got = append(got, o.RawValue())
+	}), func(v Visitee) {
+		if f, ok := v.(*NormalField); ok {
+			for _, each := range f.Options {
+				got = append(got, each.RawValue())
+			}
+		}
+	})
+	want := []string{
+		"{\n\t\ta: [1, 2]\n\t\tb { c: \"d\" }\n\t}",
+		`"x"`,
+		"{ min: -1 }",
+		"true",
+		"'end'",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%q] want [%q]", got, want)
+	}
+}
+
+func TestOptionRawValueOfLargeSource(t *testing.T) {
+	value := "{" + strings.Repeat(" a: 1", 1000) + " }"
+	p := newParserOn("// " + strings.Repeat("x", 5000) + "\noption (big) = " + value + ";\nmessage M {}")
+	p.OnUnknownOption = func(name string, pos scanner.Position) {
+		// the comment before the option is not kept
+		if got := len(p.source.buf); got > 2048 {
+			t.Errorf("got [%v] want at most [%v]", got, 2048)
+		}
+	}
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := def.Elements[0].(*Option).RawValue(), value; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if p.source.buf != nil {
+		t.Error("expected source text to be released")
+	}
+}
+
+func TestNegativeNumberOptions(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto2";
+	option (min) = -1;
+	option (ratio) = -0.25;
+	message M {
+		optional int32 a = 1 [default = -5];
+		optional double b = 2 [default = -1.5e-3];
+		optional float c = 3 [default = -inf];
+	}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	Walk(def, WithOption(func(o *Option) {
+		got = append(got, o.Constant.SourceRepresentation())
+	}), func(v Visitee) {
+		if f, ok := v.(*NormalField); ok {
+			got = append(got, f.Options[0].Constant.SourceRepresentation())
+		}
+	})
+	if want := []string{"-1", "-0.25", "-5", "-1.5e-3", "-inf"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestEffectiveOptions(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto3";
+	option features.field_presence = IMPLICIT;
+	option features = { enum_type: CLOSED };
+	option go_package = "acme";
+	message M {
+		option deprecated = true;
+		option features.field_presence = EXPLICIT;
+		option (my.rule) = 1;
+		string a = 1;
+		string b = 2 [features.field_presence = LEGACY_REQUIRED, json_name = "bee"];
+	}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := def.Elements[4].(*Message)
+	sources := func(options map[string]*Literal) map[string]string {
+		m := map[string]string{}
+		for k, v := range options {
+			m[k] = v.SourceRepresentation()
+		}
+		return m
+	}
+	for i, each := range []struct {
+		v    Visitee
+		want map[string]string
+	}{
+		{def, map[string]string{"features.field_presence": "IMPLICIT", "features.enum_type": "CLOSED", "go_package": `"acme"`}},
+		{m, map[string]string{"features.field_presence": "EXPLICIT", "features.enum_type": "CLOSED", "deprecated": "true", "(my.rule)": "1"}},
+		{m.Elements[3], map[string]string{"features.field_presence": "EXPLICIT", "features.enum_type": "CLOSED", "deprecated": "true"}},
+		{m.Elements[4], map[string]string{"features.field_presence": "LEGACY_REQUIRED", "features.enum_type": "CLOSED", "deprecated": "true", "json_name": `"bee"`}},
+	} {
+		if got := sources(EffectiveOptions(each.v)); !reflect.DeepEqual(got, each.want) {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, each.want)
+		}
+	}
+}
+
+func TestOptionQualifiedEnumConstantValue(t *testing.T) {
+	def, err := newParserOn(`option (x) = my.pkg.MyEnum.VALUE;
+	message M {
+		string a = 1 [(y) = .my.pkg.MyEnum.OTHER, (z) = { kind: my.pkg.MyEnum.VALUE }];
+	}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := def.Elements[0].(*Option)
+	if got, want := o.Constant.Source, "my.pkg.MyEnum.VALUE"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if o.Constant.IsString {
+		t.Error("value must not be a string")
+	}
+	f := def.Elements[1].(*Message).Elements[0].(*NormalField)
+	if got, want := f.Options[0].Constant.SourceRepresentation(), ".my.pkg.MyEnum.OTHER"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	kind, ok := f.Options[1].Constant.OrderedMap.Get("kind")
+	if !ok {
+		t.Fatal("missing kind")
+	}
+	if got, want := kind.SourceRepresentation(), "my.pkg.MyEnum.VALUE"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
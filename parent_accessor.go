@@ -86,3 +86,15 @@ func (p *parentAccessor) VisitExtensions(e *Extensions) {
 	p.parent = e.Parent
 }
 func (p *parentAccessor) VisitProto(*Proto) {}
+
+// RootProto returns the Proto that contains the Visitee by walking up its parents.
+// Returns nil if the chain of parents is broken, e.g. for an element that was not added to a Proto.
+func RootProto(v Visitee) *Proto {
+	for v != nil {
+		if p, ok := v.(*Proto); ok {
+			return p
+		}
+		v = getParent(v)
+	}
+	return nil
+}
@@ -122,3 +122,35 @@ func (pc *parentChecker) VisitGroup(g *Group) {
 func (pc *parentChecker) VisitExtensions(e *Extensions) {
 	pc.check("Extensions", "", e.Parent)
 }
+
+func TestRootProto(t *testing.T) {
+	src := `message A {
+		message B {
+			oneof choice {
+				string c = 1;
+			}
+		}
+	}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var field *OneOfField
+	Walk(def, func(v Visitee) {
+		if f, ok := v.(*OneOfField); ok {
+			field = f
+		}
+	})
+	if got, want := RootProto(field), def; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := RootProto(def), def; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got := RootProto(newNormalField()); got != nil {
+		t.Errorf("got [%v] want nil", got)
+	}
+	if got := RootProto(nil); got != nil {
+		t.Errorf("got [%v] want nil", got)
+	}
+}
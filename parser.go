@@ -40,6 +40,37 @@ type Parser struct {
 	scanner       *scanner.Scanner
 	buf           *nextValues
 	scannerErrors []error
+	// source holds the text read by the scanner from the current token on, or from the start of an option value, see RawValue
+	source *sourceRecorder
+
+	// AllowBareBoolOptions makes an option without assignment, e.g. [deprecated], parse as having the value true.
+	// This is not valid proto but accepted by some tools.
+	AllowBareBoolOptions bool
+
+	// AllowMultilineStrings makes a double quoted string that contains newlines parse as one string.
+	// Each newline is replaced by the escape sequence \n. This is not valid proto.
+	AllowMultilineStrings bool
+
+	// AutoNumberEnums makes an enum value without number, e.g. RED;, parse as having the number after that of the previous value.
+	// The first value gets 0. This is not valid proto but used by some DSLs.
+	AutoNumberEnums bool
+
+	// ParseCommentTags makes the @tag value lines of each /** */ doc block comment available in Comment.Tags.
+	ParseCommentTags bool
+
+	// StrictSyntax makes constructs that are not allowed by the proto3 syntax, such as required fields, a parse error.
+	// By default these parse without error; use CheckSyntaxFeatures or Check to report them.
+	StrictSyntax bool
+
+	// OnUnknownOption is called for each option whose name is not one of the builtin options of descriptor.proto,
+	// such as a custom option (my.option). Such options are parsed as usual.
+	OnUnknownOption func(name string, pos scanner.Position)
+
+	// unterminatedString is set when the scanner reported a string literal that ends at a newline.
+	unterminatedString bool
+
+	// docBlockTags has the tags of each /** */ comment by its position, if ParseCommentTags is set.
+	docBlockTags map[scanner.Position]map[string]string
 }
 
 // nextValues is to capture the result of next()
@@ -51,16 +82,22 @@ type nextValues struct {
 
 // NewParser returns a new instance of Parser.
 func NewParser(r io.Reader) *Parser {
+	src := new(sourceRecorder)
 	s := new(scanner.Scanner)
-	s.Init(r)
+	s.Init(io.TeeReader(r, src))
 	s.Mode = scanner.ScanIdents | scanner.ScanFloats | scanner.ScanStrings | scanner.ScanRawStrings | scanner.ScanComments
-	p := &Parser{scanner: s}
+	p := &Parser{scanner: s, source: src}
 	s.Error = p.handleScanError
 	return p
 }
 
 // handleScanError is called from the underlying Scanner
 func (p *Parser) handleScanError(s *scanner.Scanner, msg string) {
+	if p.AllowMultilineStrings && msg == "literal not terminated" {
+		// next will continue scanning the string
+		p.unterminatedString = true
+		return
+	}
 	p.scannerErrors = append(p.scannerErrors,
 		fmt.Errorf("go scanner error at %v = %v", s.Position, msg))
 }
@@ -89,6 +126,16 @@ func (p *Parser) Parse() (*Proto, error) {
 		proto.Filename = p.scanner.Filename
 	}
 	parseError := proto.parse(p)
+	// the text is only needed while parsing
+	p.source.buf = nil
+	if parseError == nil && p.StrictSyntax {
+		if list := CheckSyntaxFeatures(proto); len(list) > 0 {
+			parseError = list[0]
+		}
+	}
+	if len(p.docBlockTags) > 0 {
+		setCommentTags(proto, p.docBlockTags)
+	}
 	// see if it was a scanner error
 	if len(p.scannerErrors) > 0 {
 		buf := new(bytes.Buffer)
@@ -120,45 +167,116 @@ func (p *Parser) next() (pos scanner.Position, tok token, lit string) {
 		return p.scanner.Position, tEOF, ""
 	}
 	lit = p.scanner.TokenText()
+	p.source.discardBefore(p.scanner.Position.Offset)
+	if p.unterminatedString {
+		p.unterminatedString = false
+		return p.nextMultilineString(lit)
+	}
 	// single quote needs additional scanning
 	if stringWithSingleQuote == lit {
 		return p.nextSingleQuotedString()
 	}
+	if p.ParseCommentTags && isDocBlock(lit) {
+		if p.docBlockTags == nil {
+			p.docBlockTags = map[scanner.Position]map[string]string{}
+		}
+		p.docBlockTags[p.scanner.Position] = commentTags(lit)
+	}
 	return p.scanner.Position, asToken(lit), lit
 }
 
 // pre: first single quote has been read
+// The characters up to the closing single quote are read as is, including whitespace.
 func (p *Parser) nextSingleQuotedString() (pos scanner.Position, tok token, lit string) {
-	ch := p.scanner.Scan()
-	if ch == scanner.EOF {
-		return p.scanner.Position, tEOF, ""
-	}
-	// string inside single quote
-	lit = p.scanner.TokenText()
-	if stringWithSingleQuote == lit {
-		// empty single quoted string
-		return p.scanner.Position, tIDENT, "''"
-	}
-
-	// scan for partial tokens until actual closing single-quote(') token
+	pos = p.scanner.Position
+	buf := new(bytes.Buffer)
+	buf.WriteString(stringWithSingleQuote)
 	for {
-		ch = p.scanner.Scan()
-
-		if ch == scanner.EOF {
+		ch := p.scanner.Next()
+		switch ch {
+		case scanner.EOF:
 			return p.scanner.Position, tEOF, ""
+		case '\\':
+			// keep escape sequence, including an escaped single quote
+			buf.WriteRune(ch)
+			if next := p.scanner.Next(); next != scanner.EOF {
+				buf.WriteRune(next)
+			}
+		case '\'':
+			buf.WriteRune(ch)
+			return pos, tIDENT, buf.String()
+		default:
+			buf.WriteRune(ch)
 		}
+	}
+}
 
-		partial := p.scanner.TokenText()
-		if partial == "'" {
-			break
+// nextMultilineString continues scanning a double quoted string that was not terminated on its first line.
+// pre: partial is the scanned text of the string up to and including the first newline
+func (p *Parser) nextMultilineString(partial string) (pos scanner.Position, tok token, lit string) {
+	pos = p.scanner.Position
+	buf := new(bytes.Buffer)
+	buf.WriteString(strings.TrimSuffix(partial, "\n"))
+	buf.WriteString(`\n`)
+	for {
+		ch := p.scanner.Next()
+		switch ch {
+		case scanner.EOF:
+			p.scannerErrors = append(p.scannerErrors,
+				fmt.Errorf("go scanner error at %v = %v", pos, "literal not terminated"))
+			return pos, tIDENT, buf.String()
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\\':
+			// keep escape sequence, including an escaped quote
+			buf.WriteRune(ch)
+			if next := p.scanner.Next(); next != scanner.EOF {
+				buf.WriteRune(next)
+			}
+		case '"':
+			buf.WriteRune(ch)
+			return pos, tIDENT, buf.String()
+		default:
+			buf.WriteRune(ch)
 		}
-		lit += partial
 	}
-	// end quote expected
-	if stringWithSingleQuote != p.scanner.TokenText() {
-		p.unexpected(lit, "'", p)
+}
+
+// sourceRecorder is written the text that the scanner reads.
+// It keeps the text from the current token on, or everything while recording.
+type sourceRecorder struct {
+	buf       []byte
+	offset    int // offset in the source of buf[0]
+	recording bool
+}
+
+// Write is part of io.Writer
+func (r *sourceRecorder) Write(b []byte) (int, error) {
+	r.buf = append(r.buf, b...)
+	return len(b), nil
+}
+
+// discardBefore drops the text before the offset unless recording.
+func (r *sourceRecorder) discardBefore(offset int) {
+	if r.recording || offset <= r.offset {
+		return
+	}
+	n := offset - r.offset
+	if n > len(r.buf) {
+		n = len(r.buf)
+	}
+	r.buf = r.buf[n:]
+	r.offset += n
+}
+
+// sourceBetween returns the source text from offset start up to offset end, without surrounding whitespace.
+// Returns empty if that text is no longer kept.
+func (p *Parser) sourceBetween(start, end int) string {
+	r := p.source
+	if start < r.offset || end > r.offset+len(r.buf) || start > end {
+		return ""
 	}
-	return p.scanner.Position, tIDENT, fmt.Sprintf("'%s'", lit)
+	return strings.TrimSpace(string(r.buf[start-r.offset : end-r.offset]))
 }
 
 // nextPut sets the buffer
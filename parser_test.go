@@ -181,3 +181,66 @@ func TestParseSingleQuotesStrings(t *testing.T) {
 		t.Errorf("got [%v] want [%v]", got, want)
 	}
 }
+
+func TestParseMultilineString(t *testing.T) {
+	src := "message M {\n  string a = 1 [(description) = \"first line\n  second \\\"line\\\"\"];\n}"
+	p := newParserOn(src)
+	p.AllowMultilineStrings = true
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := def.Elements[0].(*Message).Elements[0].(*NormalField)
+	if got, want := f.Options[0].Constant.Source, `first line\n  second \"line\"`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := f.Options[0].Constant.IsString, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestParseMultilineStringNotAllowed(t *testing.T) {
+	src := "option (description) = \"first line\nsecond line\";"
+	if _, err := newParserOn(src).Parse(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestParseMultilineStringNotTerminated(t *testing.T) {
+	p := newParserOn("option (description) = \"first line\nsecond line;")
+	p.AllowMultilineStrings = true
+	if _, err := p.Parse(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestStrictSyntax(t *testing.T) {
+	src := `syntax = "proto3";
+message Account {
+	required string id = 1;
+	string name = 2 [default = "none"];
+}`
+	p := newParserOn(src)
+	p.StrictSyntax = true
+	if _, err := p.Parse(); err == nil || !strings.HasPrefix(err.Error(), "<input>:3:11: ") {
+		t.Fatalf("expected error for required field in proto3, got %v", err)
+	}
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := def.Elements[1].(*Message).Elements[0].(*NormalField)
+	if !id.Required {
+		t.Error("expected required field")
+	}
+	list := CheckSyntaxFeatures(def)
+	if got, want := len(list), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := list[0].Error(), `<input>:3:11: field "id" cannot be required in proto3`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := errorPosition(list[1]).Line, 4; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
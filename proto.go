@@ -154,3 +154,89 @@ type elementContainer interface {
 	elements() []Visitee
 	takeLastComment(expectedOnLine int) *Comment
 }
+
+// packageName returns the name of the first package declaration or empty if absent.
+func (proto *Proto) packageName() string {
+	for _, each := range proto.Elements {
+		if pkg, ok := each.(*Package); ok {
+			return pkg.Name
+		}
+	}
+	return ""
+}
+
+// syntaxValue returns the value of the syntax declaration ; defaults to proto2 if absent.
+func (proto *Proto) syntaxValue() string {
+	for _, each := range proto.Elements {
+		if s, ok := each.(*Syntax); ok {
+			return s.Value
+		}
+	}
+	return "proto2"
+}
+
+// ServiceNames returns the fully qualified name (package.Service) of each service.
+// Without a package declaration, the names are not qualified.
+func (proto *Proto) ServiceNames() (list []string) {
+	pkg := proto.packageName()
+	for _, each := range proto.Elements {
+		if s, ok := each.(*Service); ok {
+			if len(pkg) > 0 {
+				list = append(list, pkg+"."+s.Name)
+			} else {
+				list = append(list, s.Name)
+			}
+		}
+	}
+	return
+}
+
+// AllEnums returns all enums, including those nested in messages, by their fully qualified name, e.g. package.Message.Enum.
+func (proto *Proto) AllEnums() map[string]*Enum {
+	all := map[string]*Enum{}
+	Walk(proto, WithEnum(func(e *Enum) {
+		all[qualifiedName(e)] = e
+	}))
+	return all
+}
+
+// BoolFileOption returns the value of a boolean file option such as java_multiple_files or cc_enable_arenas.
+// The second result is false if the option is absent or its value is not the literal true or false.
+func (proto *Proto) BoolFileOption(name string) (bool, bool) {
+	for _, each := range proto.Elements {
+		o, ok := each.(*Option)
+		if !ok || o.Name != name || o.Constant.IsString {
+			continue
+		}
+		switch o.Constant.Source {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// AllMessages returns all message definitions, including those nested in messages at any depth, in source order.
+// Extend blocks are not included. Messages declared with option map_entry = true (as generated from a map field
+// by protoc) are only included if includeMapEntries is true.
+func (proto *Proto) AllMessages(includeMapEntries bool) (list []*Message) {
+	Walk(proto, WithMessage(func(m *Message) {
+		if m.IsExtend || (!includeMapEntries && isMapEntry(m)) {
+			return
+		}
+		list = append(list, m)
+	}))
+	return
+}
+
+// HasStreaming returns true if any service of the Proto has a streaming RPC.
+func (proto *Proto) HasStreaming() bool {
+	for _, each := range proto.Elements {
+		if s, ok := each.(*Service); ok && s.HasStreaming() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProtoServiceNames(t *testing.T) {
+	src := `syntax = "proto3";
+package acme.v1;
+service Accounts {}
+message Account {}
+service Orders {}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := def.ServiceNames(), []string{"acme.v1.Accounts", "acme.v1.Orders"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestProtoAllEnums(t *testing.T) {
+	src := `package acme;
+enum Status { UNKNOWN = 0; }
+message Order {
+	message Line {
+		enum Kind { NONE = 0; }
+	}
+	enum Status { ACTIVE = 0; }
+}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := def.AllEnums()
+	if got, want := len(all), 3; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	for name, first := range map[string]string{
+		"acme.Status":          "UNKNOWN",
+		"acme.Order.Line.Kind": "NONE",
+		"acme.Order.Status":    "ACTIVE",
+	} {
+		e, ok := all[name]
+		if !ok {
+			t.Fatalf("missing %s", name)
+		}
+		if got, want := e.Elements[0].(*EnumField).Name, first; got != want {
+			t.Errorf("[%s] got [%v] want [%v]", name, got, want)
+		}
+	}
+}
+
+func TestProtoBoolFileOption(t *testing.T) {
+	src := `syntax = "proto3";
+option java_multiple_files = true;
+option cc_enable_arenas = false;
+option py_generic_services = "true";
+option java_generic_services = TRUE;
+option cc_generic_services = true;`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, each := range []struct {
+		name         string
+		value, found bool
+	}{
+		{"java_multiple_files", true, true},
+		{"cc_enable_arenas", false, true},
+		{"cc_generic_services", true, true},
+		{"py_generic_services", false, false},
+		{"java_generic_services", false, false},
+		{"php_generic_services", false, false},
+	} {
+		value, found := def.BoolFileOption(each.name)
+		if value != each.value || found != each.found {
+			t.Errorf("[%s] got [%v,%v] want [%v,%v]", each.name, value, found, each.value, each.found)
+		}
+	}
+}
+
+func TestProtoAllMessages(t *testing.T) {
+	src := `syntax = "proto2";
+	message A {
+		message B {
+			message C {}
+			optional C c = 1;
+		}
+		message LabelsEntry {
+			option map_entry = true;
+			optional string key = 1;
+			optional string value = 2;
+		}
+		repeated LabelsEntry labels = 1;
+	}
+	extend A {
+		optional string x = 100;
+	}
+	message D {}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := func(list []*Message) (names []string) {
+		for _, each := range list {
+			names = append(names, each.Name)
+		}
+		return
+	}
+	all := def.AllMessages(false)
+	if got, want := names(all), []string{"A", "B", "C", "D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := all[2].Parent, all[1]; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := names(def.AllMessages(true)), []string{"A", "B", "C", "LabelsEntry", "D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
@@ -66,3 +66,22 @@ func TestParseRangesMultiToMax(t *testing.T) {
 		t.Errorf("got [%v] want [%v]", got, want)
 	}
 }
+
+func TestParseRangesDegenerate(t *testing.T) {
+	r := new(Reserved)
+	p := newParserOn(`reserved 5 to 5, 7;`)
+	_, _, _ = p.next()
+	ranges, err := parseRanges(p, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(ranges), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := ranges[0], (Range{From: 5, To: 5}); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := ranges[0].SourceRepresentation(), "5"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
@@ -62,3 +62,30 @@ func TestReservedFieldNames(t *testing.T) {
 		t.Errorf("got [%v] want [%v]", got, want)
 	}
 }
+
+func TestReservedSingleFieldName(t *testing.T) {
+	def, err := newParserOn(`message M {
+		reserved "legacy_field"; // gone
+		string name = 1;
+	}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := def.Elements[0].(*Message)
+	r, ok := m.Elements[0].(*Reserved)
+	if !ok {
+		t.Fatalf("got [%T] want [*Reserved]", m.Elements[0])
+	}
+	if got, want := len(r.Ranges), 0; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := r.FieldNames, []string{"legacy_field"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if r.InlineComment == nil {
+		t.Error("expected inline comment")
+	}
+	if got, want := len(m.Elements), 2; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+// Rewrite applies fn to each element, bottom-up, and returns the result of applying fn to v.
+// Each element is replaced by the result of fn ; returning the element itself leaves it unchanged and returning nil removes it.
+// A replacement gets the parent of the element it replaces. Options of fields can only be replaced by another *Option.
+func Rewrite(v Visitee, fn func(Visitee) Visitee) Visitee {
+	switch e := v.(type) {
+	case *Proto:
+		e.Elements = rewriteElements(e, e.Elements, fn)
+	case *Message:
+		e.Elements = rewriteElements(e, e.Elements, fn)
+	case *Group:
+		e.Elements = rewriteElements(e, e.Elements, fn)
+	case *Oneof:
+		e.Elements = rewriteElements(e, e.Elements, fn)
+	case *Enum:
+		e.Elements = rewriteElements(e, e.Elements, fn)
+	case *EnumField:
+		e.Elements = rewriteElements(e, e.Elements, fn)
+		e.ValueOption = lastOption(e.Elements)
+	case *Service:
+		e.Elements = rewriteElements(e, e.Elements, fn)
+	case *RPC:
+		e.Elements = rewriteElements(e, e.Elements, fn)
+		e.Options = nil
+		for _, each := range e.Elements {
+			if o, ok := each.(*Option); ok {
+				e.Options = append(e.Options, o)
+			}
+		}
+	case *NormalField:
+		e.Options = rewriteOptions(e, e.Options, fn)
+	case *MapField:
+		e.Options = rewriteOptions(e, e.Options, fn)
+	case *OneOfField:
+		e.Options = rewriteOptions(e, e.Options, fn)
+	}
+	return fn(v)
+}
+
+func rewriteElements(parent Visitee, elements []Visitee, fn func(Visitee) Visitee) (list []Visitee) {
+	for _, each := range elements {
+		if next := Rewrite(each, fn); next != nil {
+			next.parent(parent)
+			list = append(list, next)
+		}
+	}
+	return
+}
+
+func rewriteOptions(parent Visitee, options []*Option, fn func(Visitee) Visitee) (list []*Option) {
+	for _, each := range options {
+		if o, ok := Rewrite(each, fn).(*Option); ok && o != nil {
+			o.parent(parent)
+			list = append(list, o)
+		}
+	}
+	return
+}
+
+// lastOption returns the last Option of the elements ; nil if absent.
+func lastOption(elements []Visitee) (last *Option) {
+	for _, each := range elements {
+		if o, ok := each.(*Option); ok {
+			last = o
+		}
+	}
+	return
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRewrite(t *testing.T) {
+	def, err := newParserOn(`message OldOrder {
+		string id = 1;
+		string legacy = 2 [deprecated = true];
+		message OldItem {
+			// gone
+			int32 count = 1 [deprecated = true, json_name = "n"];
+		}
+	}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	replacement := &NormalField{Field: &Field{Name: "identifier", Type: "string", Sequence: 1}}
+	result := Rewrite(def, func(v Visitee) Visitee {
+		switch e := v.(type) {
+		case *Message:
+			e.Name = strings.TrimPrefix(e.Name, "Old")
+		case *NormalField:
+			if e.Name == "legacy" {
+				return nil
+			}
+			if e.Name == "id" {
+				return replacement
+			}
+		case *Option:
+			if e.Name == "deprecated" {
+				return nil
+			}
+		case *Comment:
+			return nil
+		}
+		return v
+	})
+	if result != def {
+		t.Fatalf("got [%v] want [%v]", result, def)
+	}
+	order := def.Elements[0].(*Message)
+	if got, want := order.Name, "Order"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := len(order.Elements), 2; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := order.Elements[0], Visitee(replacement); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := replacement.Parent, Visitee(order); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	item := order.Elements[1].(*Message)
+	if got, want := item.Name, "Item"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := len(item.Elements), 1; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	count := item.Elements[0].(*NormalField)
+	if got, want := len(count.Options), 1; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	if got, want := count.Options[0].Name, "json_name"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	checkParent(def, t)
+}
+
+func TestRewriteIdentity(t *testing.T) {
+	def, err := newParserOn(`message M { string a = 1; enum E { A = 0 [deprecated = true]; } }`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := ContentHash(def)
+	Rewrite(def, func(v Visitee) Visitee { return v })
+	if got, want := ContentHash(def), before; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	checkParent(def, t)
+}
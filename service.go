@@ -117,6 +117,16 @@ done:
 
 func (s *Service) parent(v Visitee) { s.Parent = v }
 
+// HasStreaming returns true if any RPC of the service streams its request or its response.
+func (s *Service) HasStreaming() bool {
+	for _, each := range s.Elements {
+		if rpc, ok := each.(*RPC); ok && (rpc.StreamsRequest || rpc.StreamsReturns) {
+			return true
+		}
+	}
+	return false
+}
+
 // RPC represents an rpc entry in a message.
 type RPC struct {
 	Position       scanner.Position
@@ -153,7 +163,9 @@ func (r *RPC) inlineComment(c *Comment) {
 func (r *RPC) parse(p *Parser) error {
 	pos, tok, lit := p.next()
 	if tok != tIDENT {
-		return p.unexpected(lit, "rpc method", r)
+		if !isKeyword(tok) {
+			return p.unexpected(lit, "rpc method", r)
+		}
 	}
 	r.Name = lit
 	pos, tok, lit = p.next()
@@ -161,11 +173,11 @@ func (r *RPC) parse(p *Parser) error {
 		return p.unexpected(lit, "rpc type opening (", r)
 	}
 	pos, tok, lit = p.nextTypeName()
-	if tSTREAM == tok {
+	if tSTREAM == tok && p.peekNonWhitespace() != ')' {
 		r.StreamsRequest = true
 		pos, tok, lit = p.nextTypeName()
 	}
-	if tok != tIDENT {
+	if tok != tIDENT && tok != tSTREAM {
 		return p.unexpected(lit, "rpc stream | request type", r)
 	}
 	r.RequestType = lit
@@ -182,11 +194,11 @@ func (r *RPC) parse(p *Parser) error {
 		return p.unexpected(lit, "rpc type opening (", r)
 	}
 	pos, tok, lit = p.nextTypeName()
-	if tSTREAM == tok {
+	if tSTREAM == tok && p.peekNonWhitespace() != ')' {
 		r.StreamsReturns = true
 		pos, tok, lit = p.nextTypeName()
 	}
-	if tok != tIDENT {
+	if tok != tIDENT && tok != tSTREAM {
 		return p.unexpected(lit, "rpc stream | returns type", r)
 	}
 	r.ReturnsType = lit
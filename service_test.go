@@ -208,3 +208,70 @@ func TestServiceInlineCommentBeforeBody(t *testing.T) {
 		t.Errorf("got %d want %d lines", got, want)
 	}
 }
+
+func TestRPCWithStreamAsIdentifier(t *testing.T) {
+	src := `service S {
+		rpc stream(stream) returns (stream stream);
+		rpc Watch(stream stream.Request) returns (stream.Response);
+	}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := collect(def).Services()[0]
+	first := s.Elements[0].(*RPC)
+	if got, want := first.Name, "stream"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := first.RequestType, "stream"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := first.StreamsRequest, false; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := first.ReturnsType, "stream"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := first.StreamsReturns, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	second := s.Elements[1].(*RPC)
+	if got, want := second.RequestType, "stream.Request"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := second.StreamsRequest, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := second.ReturnsType, "stream.Response"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := second.StreamsReturns, false; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestServiceHasStreaming(t *testing.T) {
+	def, err := newParserOn(`service Unary {
+		rpc Get(Req) returns (Resp);
+	}
+	service Mixed {
+		rpc Get(Req) returns (Resp);
+		rpc Watch(Req) returns (stream Resp);
+	}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unary := def.Elements[0].(*Service)
+	if unary.HasStreaming() {
+		t.Error("unary service must not have streaming")
+	}
+	if !def.Elements[1].(*Service).HasStreaming() {
+		t.Error("mixed service must have streaming")
+	}
+	if !def.HasStreaming() {
+		t.Error("proto must have streaming")
+	}
+	if (&Proto{Elements: []Visitee{unary}}).HasStreaming() {
+		t.Error("proto with unary service must not have streaming")
+	}
+}
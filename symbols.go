@@ -0,0 +1,235 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"io"
+	"strings"
+	"text/scanner"
+)
+
+// SymbolTable holds the message and enum types defined by a Proto and all the definitions it imports.
+type SymbolTable struct {
+	opener  func(filename string) (io.Reader, error)
+	protos  map[string]*Proto
+	symbols map[string]*Symbol
+}
+
+// Symbol is a named type definition in a SymbolTable.
+type Symbol struct {
+	// Name is the fully qualified name without leading dot, e.g. google.protobuf.Empty
+	Name string
+	// Type is either a *Message, *Enum or *Group
+	Type Visitee
+	// Proto is the definition in which the type is defined.
+	Proto *Proto
+}
+
+// NewSymbolTable returns a new SymbolTable.
+// The opener is used to read each imported file by its import path. It can be nil if imports must not be loaded.
+func NewSymbolTable(opener func(filename string) (io.Reader, error)) *SymbolTable {
+	return &SymbolTable{
+		opener:  opener,
+		protos:  map[string]*Proto{},
+		symbols: map[string]*Symbol{},
+	}
+}
+
+// Load adds all the types defined by the Proto and, using the opener, by all its (transitive) imports.
+func (t *SymbolTable) Load(p *Proto) error {
+	if _, ok := t.protos[p.Filename]; ok {
+		return nil
+	}
+	t.protos[p.Filename] = p
+	t.addSymbols(p, p)
+	if t.opener == nil {
+		return nil
+	}
+	for _, each := range p.Elements {
+		im, ok := each.(*Import)
+		if !ok {
+			continue
+		}
+		if _, ok := t.protos[im.Filename]; ok {
+			continue
+		}
+		r, err := t.opener(im.Filename)
+		if err != nil {
+			return newCheckError(im.Position, "unable to open import %q: %v", im.Filename, err)
+		}
+		parser := NewParser(r)
+		parser.Filename(im.Filename)
+		imported, err := parser.Parse()
+		if closer, ok := r.(io.Closer); ok {
+			closer.Close()
+		}
+		if err != nil {
+			return err
+		}
+		// use the import path as key, even if the opener would report a different name
+		imported.Filename = im.Filename
+		if err := t.Load(imported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *SymbolTable) addSymbols(p *Proto, container elementContainer) {
+	for _, each := range container.elements() {
+		switch e := each.(type) {
+		case *Message:
+			// an extend does not define a type but can contain groups
+			if !e.IsExtend {
+				t.addSymbol(p, e)
+			}
+		case *Enum, *Group:
+			t.addSymbol(p, e)
+		}
+		if next, ok := each.(elementContainer); ok {
+			t.addSymbols(p, next)
+		}
+	}
+}
+
+func (t *SymbolTable) addSymbol(p *Proto, v Visitee) {
+	name := qualifiedName(v)
+	t.symbols[name] = &Symbol{Name: name, Type: v, Proto: p}
+}
+
+// Lookup finds the Symbol for a type name as used within a scope, e.g. the type of a field inside its message.
+// A name with a leading dot is fully qualified. Otherwise it is searched for
+// in the scope and then in each enclosing scope, as protoc does.
+func (t *SymbolTable) Lookup(typeName string, scope Visitee) (*Symbol, bool) {
+	if strings.HasPrefix(typeName, ".") {
+		s, ok := t.symbols[typeName[1:]]
+		return s, ok
+	}
+	prefix := qualifiedName(scope)
+	for {
+		candidate := typeName
+		if len(prefix) > 0 {
+			candidate = prefix + "." + typeName
+		}
+		if s, ok := t.symbols[candidate]; ok {
+			return s, true
+		}
+		if len(prefix) == 0 {
+			return nil, false
+		}
+		if dot := strings.LastIndex(prefix, "."); dot != -1 {
+			prefix = prefix[:dot]
+		} else {
+			prefix = ""
+		}
+	}
+}
+
+// qualifiedName returns the dotted name of the scope that is formed by the Visitee and its parents, including the package.
+func qualifiedName(v Visitee) string {
+	names := []string{}
+	for v != nil {
+		switch e := v.(type) {
+		case *Message:
+			if !e.IsExtend {
+				names = append([]string{e.Name}, names...)
+			}
+		case *Enum:
+			names = append([]string{e.Name}, names...)
+		case *Group:
+			names = append([]string{e.Name}, names...)
+		case *Proto:
+			if pkg := e.packageName(); len(pkg) > 0 {
+				names = append([]string{pkg}, names...)
+			}
+			return strings.Join(names, ".")
+		}
+		v = getParent(v)
+	}
+	return strings.Join(names, ".")
+}
+
+// isScalarType returns true if the type name is one of the builtin scalar value types.
+func isScalarType(typeName string) bool {
+	for _, each := range strings.Fields(typeTokens) {
+		if each == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// typeReference is the use of a type name by an element.
+type typeReference struct {
+	Name     string
+	Position scanner.Position
+	// Scope is where the name is used, for looking it up in a SymbolTable.
+	Scope Visitee
+	// Element refers to the type by Name.
+	Element Visitee
+}
+
+// collectTypeReferences returns all non-scalar type names used by fields, rpcs and extends of a Proto.
+func collectTypeReferences(proto *Proto) (list []typeReference) {
+	add := func(name string, pos scanner.Position, scope, element Visitee) {
+		if !isScalarType(name) {
+			list = append(list, typeReference{Name: name, Position: pos, Scope: scope, Element: element})
+		}
+	}
+	Walk(proto, func(v Visitee) {
+		switch e := v.(type) {
+		case *NormalField:
+			add(e.Type, e.Position, e.Parent, e)
+		case *MapField:
+			add(e.Type, e.Position, e.Parent, e)
+		case *OneOfField:
+			add(e.Type, e.Position, e.Parent, e)
+		case *RPC:
+			add(e.RequestType, e.Position, e.Parent, e)
+			add(e.ReturnsType, e.Position, e.Parent, e)
+		case *Message:
+			if e.IsExtend {
+				add(e.Name, e.Position, e.Parent, e)
+			}
+		}
+	})
+	return
+}
+
+// addPublicImports marks the file visible and, transitively, all files it imports publicly.
+func (t *SymbolTable) addPublicImports(filename string, visible map[string]bool) {
+	if visible[filename] {
+		return
+	}
+	visible[filename] = true
+	p, ok := t.protos[filename]
+	if !ok {
+		return
+	}
+	for _, each := range p.Elements {
+		if im, ok := each.(*Import); ok && im.Kind == "public" {
+			t.addPublicImports(im.Filename, visible)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// openerOn returns an opener that reads definitions from a map of sources keyed by filename.
+func openerOn(sources map[string]string) func(string) (io.Reader, error) {
+	return func(filename string) (io.Reader, error) {
+		src, ok := sources[filename]
+		if !ok {
+			return nil, errors.New("no such file")
+		}
+		return strings.NewReader(src), nil
+	}
+}
+
+func loadSymbolTable(t *testing.T, main string, sources map[string]string) (*Proto, *SymbolTable) {
+	p := newParserOn(main)
+	p.Filename("main.proto")
+	def, err := p.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := NewSymbolTable(openerOn(sources))
+	if err := table.Load(def); err != nil {
+		t.Fatal(err)
+	}
+	return def, table
+}
+
+func TestSymbolTableLookup(t *testing.T) {
+	def, table := loadSymbolTable(t, `
+package acme;
+import "other.proto";
+message Outer {
+	message Inner {
+		enum Kind { A = 0; }
+	}
+	Inner.Kind kind = 1;
+}`, map[string]string{
+		"other.proto": `package acme.other; message Shared {}`,
+	})
+	outer := collect(def).Messages()[0]
+	for i, each := range []struct {
+		typeName string
+		scope    Visitee
+		found    string
+	}{
+		{"Inner.Kind", outer, "acme.Outer.Inner.Kind"},
+		{"Outer", outer.Elements[0], "acme.Outer"},
+		{".acme.Outer.Inner", def, "acme.Outer.Inner"},
+		{"other.Shared", outer, "acme.other.Shared"},
+		{"Missing", outer, ""},
+	} {
+		s, ok := table.Lookup(each.typeName, each.scope)
+		if got, want := ok, len(each.found) > 0; got != want {
+			t.Fatalf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if ok && s.Name != each.found {
+			t.Errorf("[%d] got [%v] want [%v]", i, s.Name, each.found)
+		}
+	}
+}
+
+func TestSymbolTableMissingImport(t *testing.T) {
+	def, err := newParserOn(`import "missing.proto";`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewSymbolTable(openerOn(nil)).Load(def); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestLookupDeeplyQualifiedType(t *testing.T) {
+	def, table := loadSymbolTable(t, `
+package app;
+import "a/b/c/d/types.proto";
+message Holder {
+	a.b.c.d.SomeMessage plain = 1;
+	.a.b.c.d.SomeMessage.Nested absolute = 2;
+	map<string, a.b.c.d.SomeMessage> byName = 3;
+}`, map[string]string{
+		"a/b/c/d/types.proto": `package a.b.c.d; message SomeMessage { message Nested {} }`,
+	})
+	holder := def.Elements[2].(*Message)
+	for i, each := range []struct {
+		typeName, found string
+	}{
+		{"a.b.c.d.SomeMessage", "a.b.c.d.SomeMessage"},
+		{".a.b.c.d.SomeMessage.Nested", "a.b.c.d.SomeMessage.Nested"},
+		{"a.b.c.d.SomeMessage", "a.b.c.d.SomeMessage"},
+	} {
+		var field *Field
+		switch f := holder.Elements[i].(type) {
+		case *NormalField:
+			field = f.Field
+		case *MapField:
+			field = f.Field
+		}
+		if got, want := field.Type, each.typeName; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		s, ok := table.Lookup(field.Type, holder)
+		if !ok {
+			t.Errorf("[%d] %s not found", i, field.Type)
+			continue
+		}
+		if got, want := s.Name, each.found; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+		if got, want := s.Proto.Filename, "a/b/c/d/types.proto"; got != want {
+			t.Errorf("[%d] got [%v] want [%v]", i, got, want)
+		}
+	}
+}
@@ -25,6 +25,7 @@ package proto
 
 import (
 	"strings"
+	"unicode"
 )
 
 // token represents a lexical token.
@@ -89,7 +90,7 @@ const (
 )
 
 // typeTokens exists for future validation
-const typeTokens = "double float int32 int64 uint32 uint64 sint32 sint64 fixed32 sfixed32 sfixed64 bool string bytes"
+const typeTokens = "double float int32 int64 uint32 uint64 sint32 sint64 fixed32 fixed64 sfixed32 sfixed64 bool string bytes"
 
 // isKeyword returns if tok is in the keywords range
 func isKeyword(tok token) bool {
@@ -104,6 +105,14 @@ func isWhitespace(r rune) bool {
 // isDigit returns true if the rune is a digit.
 func isDigit(ch rune) bool { return (ch >= '0' && ch <= '9') }
 
+// isIdentifierStart returns true if the literal starts with a letter or underscore.
+func isIdentifierStart(lit string) bool {
+	for _, each := range lit {
+		return each == '_' || unicode.IsLetter(each)
+	}
+	return false
+}
+
 // isString checks if the literal is quoted (single or double).
 func isString(lit string) bool {
 	if lit == "'" {
@@ -0,0 +1,299 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxFieldNumber is the largest field number allowed, used for ranges ending with max.
+const maxFieldNumber = 536870911
+
+// Field numbers 19000 through 19999 are reserved for the Protocol Buffers implementation.
+const (
+	firstImplementationReservedNumber = 19000
+	lastImplementationReservedNumber  = 19999
+)
+
+// Renumber assigns sequential field numbers, starting at start, to all fields of the message in the order of declaration.
+// Fields of oneofs and groups of the message are renumbered too ; nested messages are not.
+// Numbers in reserved ranges, extension ranges and the range 19000 to 19999 are skipped.
+// Renumbering breaks wire compatibility, only use it for definitions that have not been released.
+func Renumber(m *Message, start int) {
+	skip := []Range{{From: firstImplementationReservedNumber, To: lastImplementationReservedNumber}}
+	for _, each := range m.Elements {
+		switch e := each.(type) {
+		case *Reserved:
+			skip = append(skip, e.Ranges...)
+		case *Extensions:
+			skip = append(skip, e.Ranges...)
+		}
+	}
+	next := start
+	assign := func() int {
+		for inRanges(next, skip) {
+			next++
+		}
+		next++
+		return next - 1
+	}
+	renumberElements(m.Elements, assign)
+}
+
+func renumberElements(elements []Visitee, assign func() int) {
+	for _, each := range elements {
+		switch e := each.(type) {
+		case *NormalField:
+			e.Sequence = assign()
+		case *MapField:
+			e.Sequence = assign()
+		case *OneOfField:
+			e.Sequence = assign()
+		case *Group:
+			e.Sequence = assign()
+		case *Oneof:
+			renumberElements(e.Elements, assign)
+		}
+	}
+}
+
+// inRanges returns true if the number is contained in one of the ranges.
+func inRanges(number int, ranges []Range) bool {
+	for _, each := range ranges {
+		to := each.To
+		if each.Max {
+			to = maxFieldNumber
+		}
+		if each.From <= number && number <= to {
+			return true
+		}
+	}
+	return false
+}
+
+// Label is the cardinality of a field.
+type Label int
+
+// Labels of a NormalField ; LabelSingular is the absence of a label.
+const (
+	LabelSingular Label = iota
+	LabelOptional
+	LabelRequired
+	LabelRepeated
+)
+
+// String returns the keyword of the label ; empty for LabelSingular.
+func (l Label) String() string {
+	switch l {
+	case LabelOptional:
+		return "optional"
+	case LabelRequired:
+		return "required"
+	case LabelRepeated:
+		return "repeated"
+	}
+	return ""
+}
+
+// SetFieldLabel changes the label of the field.
+// Returns an error if the label is not allowed by the syntax of the Proto of the field:
+// required is not allowed in proto3 and a field without label is not allowed in proto2.
+// The field is not changed in that case.
+func SetFieldLabel(f *NormalField, label Label) error {
+	syntax := "proto2"
+	if p := RootProto(f); p != nil {
+		syntax = p.syntaxValue()
+	}
+	if syntax == "proto3" && label == LabelRequired {
+		return fmt.Errorf("%v: field %q cannot be required in proto3", f.Position, f.Name)
+	}
+	if syntax == "proto2" && label == LabelSingular {
+		return fmt.Errorf("%v: field %q must have a label in proto2", f.Position, f.Name)
+	}
+	f.Optional = label == LabelOptional
+	f.Required = label == LabelRequired
+	f.Repeated = label == LabelRepeated
+	return nil
+}
+
+// SortEnumValues reorders the values of the enum ascending by number.
+// Values with equal numbers (aliases) keep their relative order. In proto3, values with number 0 are put first
+// because the first value must be zero. In proto2 the first value is the default, so sorting can change it.
+// Comments and options of values move with their value ; other elements of the enum keep their position.
+func SortEnumValues(e *Enum) {
+	syntax := "proto2"
+	if p := RootProto(e); p != nil {
+		syntax = p.syntaxValue()
+	}
+	slots := []int{}
+	values := []*EnumField{}
+	for i, each := range e.Elements {
+		if f, ok := each.(*EnumField); ok {
+			slots = append(slots, i)
+			values = append(values, f)
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		if syntax == "proto3" && (values[i].Integer == 0) != (values[j].Integer == 0) {
+			return values[i].Integer == 0
+		}
+		return values[i].Integer < values[j].Integer
+	})
+	for i, each := range slots {
+		e.Elements[each] = values[i]
+	}
+}
+
+// unquotedFileOptions are the builtin file options whose value is a bool or an enum constant.
+var unquotedFileOptions = map[string]bool{
+	"java_multiple_files":           true,
+	"java_generate_equals_and_hash": true,
+	"java_string_check_utf8":        true,
+	"optimize_for":                  true,
+	"cc_generic_services":           true,
+	"java_generic_services":         true,
+	"py_generic_services":           true,
+	"deprecated":                    true,
+	"cc_enable_arenas":              true,
+}
+
+// EnsureFileOptions adds each file option of opts, by name, that the Proto does not declare yet.
+// Values are string constants, e.g. the import path of go_package, except for the builtin bool and enum options
+// such as java_multiple_files and optimize_for. Existing options are not changed.
+// The options are added in order of name after the package declaration or, if file options follow it, after the last of those.
+// Without package declaration, they are added after the last file option or else after the syntax declaration.
+func EnsureFileOptions(p *Proto, opts map[string]string) {
+	declared := map[string]bool{}
+	afterOption, afterPackage, afterSyntax := -1, -1, -1
+	for i, each := range p.Elements {
+		switch e := each.(type) {
+		case *Option:
+			declared[e.Name] = true
+			afterOption = i + 1
+		case *Package:
+			afterPackage = i + 1
+		case *Syntax:
+			afterSyntax = i + 1
+		}
+	}
+	at := 0
+	switch {
+	case afterPackage > afterOption:
+		at = afterPackage
+	case afterOption != -1:
+		at = afterOption
+	case afterSyntax != -1:
+		at = afterSyntax
+	}
+	names := []string{}
+	for name := range opts {
+		if !declared[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	added := []Visitee{}
+	for _, each := range names {
+		o := &Option{Name: each, Constant: Literal{Source: opts[each], IsString: !unquotedFileOptions[each]}}
+		o.parent(p)
+		added = append(added, o)
+	}
+	elements := append([]Visitee{}, p.Elements[:at]...)
+	elements = append(elements, added...)
+	p.Elements = append(elements, p.Elements[at:]...)
+}
+
+// UpdateTableOfContents puts a comment at the start of the Proto that lists each top-level message, enum and service with its line number.
+// The comment starts with a line with the begin marker and ends with a line with the end marker, e.g. "BEGIN TOC" and "END TOC".
+// A previous table of contents, as detached comment or as documentation of a top-level element, is removed first.
+// Line numbers are those of the parsed source, corrected for the new table at the top and for the removed ones,
+// so they match the source with the new table in place.
+// A Proto that already starts with a table generated by this function is left unchanged; parse the written source to update it again.
+func UpdateTableOfContents(p *Proto, begin, end string) {
+	isTOC := func(c *Comment) bool {
+		return c != nil && len(c.Lines) > 1 &&
+			strings.TrimSpace(c.Lines[0]) == begin && strings.TrimSpace(c.Lines[len(c.Lines)-1]) == end
+	}
+	if len(p.Elements) > 0 {
+		// a generated table has no source position
+		if c, ok := p.Elements[0].(*Comment); ok && isTOC(c) && c.Position.Line == 0 {
+			return
+		}
+	}
+	type entry struct {
+		kind, name string
+		line       int
+	}
+	entries := []entry{}
+	removed := []*Comment{} // previous tables of contents
+	elements := []Visitee{}
+	for _, each := range p.Elements {
+		if c, ok := each.(*Comment); ok && isTOC(c) {
+			removed = append(removed, c)
+			continue
+		}
+		elements = append(elements, each)
+		var doc **Comment
+		switch e := each.(type) {
+		case *Message:
+			doc = &e.Comment
+			if !e.IsExtend {
+				entries = append(entries, entry{"message", e.Name, e.Position.Line})
+			}
+		case *Enum:
+			doc = &e.Comment
+			entries = append(entries, entry{"enum", e.Name, e.Position.Line})
+		case *Service:
+			doc = &e.Comment
+			entries = append(entries, entry{"service", e.Name, e.Position.Line})
+		case *Syntax:
+			doc = &e.Comment
+		case *Package:
+			doc = &e.Comment
+		case *Import:
+			doc = &e.Comment
+		case *Option:
+			doc = &e.Comment
+		}
+		if doc != nil && isTOC(*doc) {
+			removed = append(removed, *doc)
+			*doc = nil
+		}
+	}
+	lines := []string{" " + begin}
+	for _, each := range entries {
+		// the new table moves all definitions down; a removed table moves the definitions below it up
+		line := each.line + len(entries) + 2
+		for _, other := range removed {
+			if other.Position.Line < each.line {
+				line -= len(other.Lines)
+			}
+		}
+		lines = append(lines, fmt.Sprintf(" %s %s (line %d)", each.kind, each.name, line))
+	}
+	lines = append(lines, " "+end)
+	p.Elements = append([]Visitee{&Comment{Lines: lines}}, elements...)
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenumber(t *testing.T) {
+	src := `message Draft {
+	reserved 2, 4 to 5;
+	// doc
+	string a = 10;
+	string b = 20;
+	oneof choice {
+		string c = 30;
+		int32 d = 40;
+	}
+	map<string, string> e = 50;
+	string f = 60;
+}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := collect(def).Messages()[0]
+	Renumber(m, 1)
+	numbers := map[string]int{}
+	Walk(def, func(v Visitee) {
+		switch e := v.(type) {
+		case *NormalField:
+			numbers[e.Name] = e.Sequence
+		case *OneOfField:
+			numbers[e.Name] = e.Sequence
+		case *MapField:
+			numbers[e.Name] = e.Sequence
+		}
+	})
+	for name, want := range map[string]int{"a": 1, "b": 3, "c": 6, "d": 7, "e": 8, "f": 9} {
+		if got := numbers[name]; got != want {
+			t.Errorf("[%s] got [%v] want [%v]", name, got, want)
+		}
+	}
+	if got, want := m.Elements[1].(*NormalField).Comment.Message(), " doc"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestRenumberSkipsImplementationRange(t *testing.T) {
+	def, err := newParserOn(`message M { string a = 1; string b = 2; }`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := collect(def).Messages()[0]
+	Renumber(m, 18999)
+	if got, want := m.Elements[1].(*NormalField).Sequence, 20000; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestSetFieldLabel(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto2"; message M { required string a = 1; }`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := collect(def).Messages()[0].Elements[0].(*NormalField)
+	if err := SetFieldLabel(f, LabelOptional); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Optional && !f.Required && !f.Repeated, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if err := SetFieldLabel(f, LabelSingular); err == nil {
+		t.Error("expected error")
+	}
+	if got, want := f.Optional, true; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestSetFieldLabelProto3(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto3"; message M { optional string a = 1; }`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := collect(def).Messages()[0].Elements[0].(*NormalField)
+	if err := SetFieldLabel(f, LabelRequired); err == nil {
+		t.Error("expected error")
+	}
+	if err := SetFieldLabel(f, LabelSingular); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Optional || f.Required || f.Repeated, false; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestSortEnumValues(t *testing.T) {
+	src := `syntax = "proto3";
+	enum Color {
+		option allow_alias = true;
+		// blue
+		BLUE = 3;
+		MINUS = -1;
+		UNKNOWN = 0;
+		RED = 1;
+		AZURE = 3;
+		GREEN = 2;
+	}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := def.Elements[1].(*Enum)
+	SortEnumValues(e)
+	if _, ok := e.Elements[0].(*Option); !ok {
+		t.Fatalf("got [%T] want [*Option]", e.Elements[0])
+	}
+	var names []string
+	for _, each := range e.Elements[1:] {
+		names = append(names, each.(*EnumField).Name)
+	}
+	if got, want := names, []string{"UNKNOWN", "MINUS", "RED", "GREEN", "BLUE", "AZURE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := e.Elements[5].(*EnumField).Comment.Message(), " blue"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestEnsureFileOptions(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto3";
+	package acme;
+	import "other.proto";
+	message M {}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	EnsureFileOptions(def, map[string]string{"go_package": "example.com/acme", "java_multiple_files": "true"})
+	if got, want := len(def.Elements), 6; got != want {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	goPackage, ok := def.Elements[2].(*Option)
+	if !ok {
+		t.Fatalf("got [%T] want [*Option]", def.Elements[2])
+	}
+	if got, want := goPackage.Name+" = "+goPackage.Constant.SourceRepresentation(), `go_package = "example.com/acme"`; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := def.Elements[3].(*Option).Constant.SourceRepresentation(), "true"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if getParent(goPackage) != def {
+		t.Error("option must have the proto as parent")
+	}
+	// existing options are kept
+	EnsureFileOptions(def, map[string]string{"go_package": "other", "java_package": "com.acme"})
+	if got, want := goPackage.Constant.Source, "example.com/acme"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := def.Elements[4].(*Option).Name, "java_package"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if _, ok := def.Elements[6].(*Message); !ok {
+		t.Errorf("got [%T] want [*Message]", def.Elements[6])
+	}
+}
+
+func TestUpdateTableOfContents(t *testing.T) {
+	src := `// TOC
+// message Old (line 1)
+// /TOC
+syntax = "proto3";
+
+// doc of Account
+message Account {}
+enum Status { NONE = 0; }
+service Accounts {}
+extend Account {}`
+	def, err := newParserOn(src).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	UpdateTableOfContents(def, "TOC", "/TOC")
+	toc, ok := def.Elements[0].(*Comment)
+	if !ok {
+		t.Fatalf("got [%T] want [*Comment]", def.Elements[0])
+	}
+	// the new block is two lines taller than the old one
+	want := []string{" TOC", " message Account (line 9)", " enum Status (line 10)", " service Accounts (line 11)", " /TOC"}
+	if !reflect.DeepEqual(toc.Lines, want) {
+		t.Errorf("got [%q] want [%q]", toc.Lines, want)
+	}
+	if got := def.Elements[1].(*Syntax).Comment; got != nil {
+		t.Errorf("got [%v] want nil", got)
+	}
+	if got, want := def.Elements[2].(*Message).Comment.Message(), " doc of Account"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	// parsing the written result again keeps the numbers
+	def, err = newParserOn(`// TOC
+// message Account (line 9)
+// enum Status (line 10)
+// service Accounts (line 11)
+// /TOC
+syntax = "proto3";
+
+// doc of Account
+message Account {}
+enum Status { NONE = 0; }
+service Accounts {}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	UpdateTableOfContents(def, "TOC", "/TOC")
+	if got, want := len(def.Elements), 5; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if !reflect.DeepEqual(def.Elements[0].(*Comment).Lines, want) {
+		t.Errorf("got [%q] want [%q]", def.Elements[0].(*Comment).Lines, want)
+	}
+}
+
+func TestUpdateTableOfContentsInMiddle(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto3";
+message A {}
+// TOC
+// message A (line 1)
+// message B (line 2)
+// /TOC
+message B {}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	UpdateTableOfContents(def, "TOC", "/TOC")
+	want := []string{" TOC", " message A (line 6)", " message B (line 7)", " /TOC"}
+	if got := def.Elements[0].(*Comment).Lines; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%q] want [%q]", got, want)
+	}
+	if got := def.Elements[3].(*Message).Comment; got != nil {
+		t.Errorf("got [%v] want nil", got)
+	}
+	// a second call keeps the generated table
+	UpdateTableOfContents(def, "TOC", "/TOC")
+	if got, want := len(def.Elements), 4; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got := def.Elements[0].(*Comment).Lines; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%q] want [%q]", got, want)
+	}
+}
+
+func TestUpdateTableOfContentsFirstRun(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto3";
+message Account {}
+enum Status { NONE = 0; }`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	UpdateTableOfContents(def, "TOC", "/TOC")
+	want := []string{" TOC", " message Account (line 6)", " enum Status (line 7)", " /TOC"}
+	if got := def.Elements[0].(*Comment).Lines; !reflect.DeepEqual(got, want) {
+		t.Errorf("got [%q] want [%q]", got, want)
+	}
+}
+
+func TestEnsureFileOptionsOptionBeforePackage(t *testing.T) {
+	def, err := newParserOn(`syntax = "proto3";
+	option java_package = "com.acme";
+	package acme;
+	message M {}`).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	EnsureFileOptions(def, map[string]string{"go_package": "example.com/acme"})
+	if _, ok := def.Elements[2].(*Package); !ok {
+		t.Fatalf("got [%T] want [*Package]", def.Elements[2])
+	}
+	o, ok := def.Elements[3].(*Option)
+	if !ok {
+		t.Fatalf("got [%T] want [*Option]", def.Elements[3])
+	}
+	if got, want := o.Name, "go_package"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := def.Elements[1].(*Option).Name, "java_package"; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
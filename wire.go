@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+// WireType is the protobuf encoding type of a field on the wire.
+// https://developers.google.com/protocol-buffers/docs/encoding#structure
+type WireType int
+
+// Wire types as encoded in the tag of a field.
+const (
+	WireVarint     WireType = 0
+	WireFixed64    WireType = 1
+	WireBytes      WireType = 2
+	WireStartGroup WireType = 3
+	WireEndGroup   WireType = 4
+	WireFixed32    WireType = 5
+)
+
+// wireTypeOfScalar returns the wire type of a scalar type and whether it is a scalar.
+func wireTypeOfScalar(typeName string) (WireType, bool) {
+	switch typeName {
+	case "int32", "int64", "uint32", "uint64", "sint32", "sint64", "bool":
+		return WireVarint, true
+	case "fixed64", "sfixed64", "double":
+		return WireFixed64, true
+	case "fixed32", "sfixed32", "float":
+		return WireFixed32, true
+	case "string", "bytes":
+		return WireBytes, true
+	}
+	return WireBytes, false
+}
+
+// WireType returns the wire type of the field values, using the table to resolve the type of the field.
+// Enums are varint encoded and messages are length-delimited. An unresolved type is assumed to be a message.
+// Packed repeated fields of numeric types are length-delimited ; in proto3 these are packed unless the packed option is false.
+func (f *NormalField) WireType(table *SymbolTable) WireType {
+	wt, ok := wireTypeOfScalar(f.Type)
+	if !ok {
+		s, found := table.Lookup(f.Type, f.Parent)
+		if !found {
+			return WireBytes
+		}
+		if _, isEnum := s.Type.(*Enum); !isEnum {
+			return WireBytes
+		}
+		wt = WireVarint
+	}
+	if wt != WireBytes && f.Repeated && f.isPacked() {
+		return WireBytes
+	}
+	return wt
+}
+
+// isPacked returns whether the packed option is set or, if absent, whether the syntax is proto3.
+func (f *NormalField) isPacked() bool {
+	for _, each := range f.Options {
+		if each.Name == "packed" {
+			return each.Constant.Source == "true"
+		}
+	}
+	if p := RootProto(f); p != nil {
+		return p.syntaxValue() == "proto3"
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Ernest Micklei
+//
+// MIT License
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proto
+
+import "testing"
+
+func TestNormalFieldWireType(t *testing.T) {
+	def, table := loadSymbolTable(t, `
+syntax = "proto3";
+message Sample {
+	enum Kind { UNKNOWN = 0; }
+	int32 a = 1;
+	sint64 b = 2;
+	bool c = 3;
+	double d = 4;
+	sfixed64 e = 5;
+	float f = 6;
+	fixed32 g = 7;
+	string h = 8;
+	bytes i = 9;
+	Kind j = 10;
+	Sample k = 11;
+	repeated int32 l = 12;
+	repeated int32 m = 13 [packed = false];
+	repeated string n = 14;
+}`, nil)
+	m := collect(def).Messages()[0]
+	for i, each := range []WireType{
+		WireVarint, WireVarint, WireVarint,
+		WireFixed64, WireFixed64,
+		WireFixed32, WireFixed32,
+		WireBytes, WireBytes,
+		WireVarint, WireBytes,
+		WireBytes, WireVarint, WireBytes,
+	} {
+		f := m.Elements[i+1].(*NormalField)
+		if got, want := f.WireType(table), each; got != want {
+			t.Errorf("[%s] got [%v] want [%v]", f.Name, got, want)
+		}
+	}
+}
+
+func TestNormalFieldWireTypeProto2Packed(t *testing.T) {
+	def, table := loadSymbolTable(t, `
+syntax = "proto2";
+message Sample {
+	repeated fixed32 a = 1;
+	repeated fixed32 b = 2 [packed = true];
+}`, nil)
+	m := collect(def).Messages()[0]
+	if got, want := m.Elements[0].(*NormalField).WireType(table), WireFixed32; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := m.Elements[1].(*NormalField).WireType(table), WireBytes; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}